@@ -0,0 +1,134 @@
+package wasp_wallet_sdk
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// Account gives typed access to the account methods of a wallet account
+type Account struct {
+	wallet *Wallet
+	index  uint32
+}
+
+// Account returns the account with the given index. Whether it exists is checked on the first call.
+func (s *Wallet) Account(accountIndex uint32) *Account {
+	return &Account{
+		wallet: s,
+		index:  accountIndex,
+	}
+}
+
+func (a *Account) Index() uint32 {
+	return a.index
+}
+
+func (a *Account) Details() (*types.AccountDetails, error) {
+	return a.wallet.GetAccount(types.AccountIndexIdentifier(a.index))
+}
+
+// UnspentOutputs returns the unspent outputs of the account, filter is optional
+func (a *Account) UnspentOutputs(filter *types.FilterOptions) ([]types.OutputData, error) {
+	outputs, err := callAccountMethod[[]types.OutputData](a, methods.UnspentOutputsMethod(methods.OutputsMethodData{
+		FilterOptions: filter,
+	}))
+	if err != nil || outputs == nil {
+		return nil, err
+	}
+
+	return *outputs, nil
+}
+
+// Sync synchronizes the account with the node and returns the updated balance, options are optional
+func (a *Account) Sync(ctx context.Context, options *types.SyncOptions) (*types.Balance, error) {
+	return callAccountMethodWithContext[types.Balance](ctx, a, methods.SyncMethod(methods.SyncMethodData{
+		Options: options,
+	}))
+}
+
+// Balance returns the balance of the account as of the last sync
+func (a *Account) Balance() (*types.Balance, error) {
+	return callAccountMethod[types.Balance](a, methods.GetBalanceMethod())
+}
+
+// SendOutputs sends the outputs in a single transaction, inputs and remainder are selected by the SDK
+func (a *Account) SendOutputs(outputs []types.OutputDataOutput, options *types.TransactionOptions) (*types.Transaction, error) {
+	return callAccountMethod[types.Transaction](a, methods.SendOutputsMethod(methods.SendOutputsMethodData{
+		Outputs: outputs,
+		Options: options,
+	}))
+}
+
+// Send sends base coins to other addresses in a single transaction
+func (a *Account) Send(params []types.SendParams, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareSendMethod(methods.PrepareSendMethodData{
+		Params:  params,
+		Options: options,
+	}))
+}
+
+// prepareAndSubmit prepares a transaction with the given prepare method, then signs and submits it
+func (a *Account) prepareAndSubmit(prepareMethod types.BaseCallAccountMethodWrap[any]) (*types.Transaction, error) {
+	preparedTransactionData, err := callAccountMethod[json.RawMessage](a, prepareMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.signAndSubmit(*preparedTransactionData)
+}
+
+func (a *Account) signAndSubmit(preparedTransactionData json.RawMessage) (*types.Transaction, error) {
+	return callAccountMethod[types.Transaction](a, methods.SignAndSubmitTransactionMethod(methods.SignAndSubmitTransactionMethodData{
+		PreparedTransactionData: preparedTransactionData,
+	}))
+}
+
+// waitForInclusion blocks until the transaction got included and syncs the account afterwards, so its outputs can be used
+func (a *Account) waitForInclusion(ctx context.Context, transaction *types.Transaction) error {
+	_, err := a.RetryTransactionUntilIncluded(ctx, transaction.TransactionId, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.Sync(ctx, nil)
+
+	return err
+}
+
+func callAccountMethod[T any](a *Account, method types.BaseCallAccountMethodWrap[any]) (*T, error) {
+	result, free, err := a.wallet.callAccountMethod(context.Background(), a.index, method)
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[T](result, err)
+}
+
+func callAccountMethodWithContext[T any](ctx context.Context, a *Account, method types.BaseCallAccountMethodWrap[any]) (*T, error) {
+	result, free, err := callWithContext(ctx, func() ([]byte, func(), error) {
+		return a.wallet.callAccountMethod(ctx, a.index, method)
+	})
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[T](result, err)
+}
+
+// awaitAccountMethod is callAccountMethodWithContext for methods with side effects, it waits for the result once the call started
+func awaitAccountMethod[T any](ctx context.Context, a *Account, method types.BaseCallAccountMethodWrap[any]) (*T, error) {
+	result, free, err := awaitCall(ctx, func() ([]byte, func(), error) {
+		return a.wallet.callAccountMethod(ctx, a.index, method)
+	})
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[T](result, err)
+}
@@ -0,0 +1,137 @@
+package wasp_wallet_sdk
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// AliasGovernanceParams describes a governance transition, empty fields keep the current value
+type AliasGovernanceParams struct {
+	// Bech32 encoded address of the new state controller
+	StateController string
+
+	// Bech32 encoded address of the new governor
+	Governor string
+
+	// Metadata replaces the metadata feature of the alias, an empty value removes it
+	Metadata *types.HexEncodedString
+}
+
+// CreateAliasOutput creates a new alias controlled by the account, params are optional
+func (a *Account) CreateAliasOutput(params *types.AliasOutputParams, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareCreateAliasOutputMethod(methods.PrepareCreateAliasOutputMethodData{
+		Params:  params,
+		Options: options,
+	}))
+}
+
+// Aliases returns the aliases controlled by the account as of the last sync
+func (a *Account) Aliases() ([]types.Alias, error) {
+	outputs, err := a.aliasOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]types.Alias, 0, len(outputs))
+	for _, output := range outputs {
+		alias, err := types.NewAlias(output)
+		if err != nil {
+			return nil, err
+		}
+
+		aliases = append(aliases, *alias)
+	}
+
+	return aliases, nil
+}
+
+// TransitionAliasState replaces the state metadata of an alias and increments its state index.
+// It's signed with the key of the state controller address, which has to belong to the account.
+func (a *Account) TransitionAliasState(aliasId types.HexEncodedString, stateMetadata types.HexEncodedString, options *types.TransactionOptions) (*types.Transaction, error) {
+	outputData, err := a.aliasOutput(aliasId)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := types.NewAliasStateTransition(*outputData, stateMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.SendOutputs([]types.OutputDataOutput{output}, options)
+}
+
+// TransitionAliasGovernance replaces the state controller, governor or metadata of an alias, e.g. to rotate the governor of a chain.
+// It's signed by the secret manager of the wallet (Stronghold or Ledger) with the key of the current governor address,
+// which has to belong to the account.
+func (a *Account) TransitionAliasGovernance(aliasId types.HexEncodedString, params AliasGovernanceParams, options *types.TransactionOptions) (*types.Transaction, error) {
+	outputData, err := a.aliasOutput(aliasId)
+	if err != nil {
+		return nil, err
+	}
+
+	stateController, err := a.parseOptionalBech32Address(params.StateController)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state controller: %w", err)
+	}
+
+	governor, err := a.parseOptionalBech32Address(params.Governor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid governor: %w", err)
+	}
+
+	output, err := types.NewAliasGovernanceTransition(*outputData, stateController, governor, params.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.SendOutputs([]types.OutputDataOutput{output}, options)
+}
+
+// DestroyAlias destroys an alias, it must not control any foundries anymore.
+// Destroying needs the governor's signature, the storage deposit is returned to the account.
+func (a *Account) DestroyAlias(aliasId types.HexEncodedString, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareBurnMethod(methods.PrepareBurnMethodData{
+		Burn: types.Burn{
+			Aliases: []types.HexEncodedString{aliasId},
+		},
+		Options: options,
+	}))
+}
+
+func (a *Account) aliasOutputs() ([]types.OutputData, error) {
+	return a.UnspentOutputs(&types.FilterOptions{
+		OutputTypes: []types.OutputType{types.OutputTypeAlias},
+	})
+}
+
+// aliasOutput finds the unspent output of an alias, including aliases whose ID is still null in their output
+func (a *Account) aliasOutput(aliasId types.HexEncodedString) (*types.OutputData, error) {
+	outputs, err := a.aliasOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, output := range outputs {
+		alias, err := types.NewAlias(output)
+		if err != nil {
+			return nil, err
+		}
+
+		if alias.AliasId == aliasId {
+			return &output, nil
+		}
+	}
+
+	return nil, fmt.Errorf("alias %s is not controlled by account %d", aliasId, a.index)
+}
+
+func (a *Account) parseOptionalBech32Address(address string) (*types.Address, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	return a.wallet.sdk.Utils().ParseBech32Address(address)
+}
@@ -0,0 +1,51 @@
+package wasp_wallet_sdk
+
+import (
+	"context"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// ClaimableOutputs returns the outputs sent to the account which can be claimed now, as of the last sync.
+// Withdrawals from Wasp chains arrive as such outputs.
+func (a *Account) ClaimableOutputs(filter types.ClaimableOutputsFilter) ([]types.ClaimableOutput, error) {
+	outputIds, err := callAccountMethod[[]types.OutputId](a, methods.ClaimableOutputsMethod(methods.ClaimableOutputsMethodData{
+		OutputsToClaim: filter,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	claimableOutputs := make([]types.ClaimableOutput, 0, len(*outputIds))
+	for _, outputId := range *outputIds {
+		outputData, err := callAccountMethod[types.OutputData](a, methods.GetOutputMethod(methods.GetOutputMethodData{
+			OutputId: outputId,
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		claimableOutput, err := types.NewClaimableOutput(*outputData)
+		if err != nil {
+			return nil, err
+		}
+
+		claimableOutputs = append(claimableOutputs, *claimableOutput)
+	}
+
+	return claimableOutputs, nil
+}
+
+// ClaimOutputs claims the outputs in a single transaction, storage deposits are returned to their senders.
+// A context that is done once the transaction is being sent doesn't abort it, the transaction is returned anyway.
+func (a *Account) ClaimOutputs(ctx context.Context, outputIds []types.OutputId) (*types.Transaction, error) {
+	outputIdsToClaim := make([]string, len(outputIds))
+	for index, outputId := range outputIds {
+		outputIdsToClaim[index] = string(outputId)
+	}
+
+	return awaitAccountMethod[types.Transaction](ctx, a, methods.ClaimOutputsMethod(methods.ClaimOutputsMethodData{
+		OutputIdsToClaim: outputIdsToClaim,
+	}))
+}
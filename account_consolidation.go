@@ -0,0 +1,66 @@
+package wasp_wallet_sdk
+
+import (
+	"math/big"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// ConsolidateOutputs merges the basic outputs of the account into a single output, freeing their storage deposits.
+// Without Force, it fails if there are less outputs than the threshold.
+func (a *Account) ConsolidateOutputs(params types.ConsolidationParams) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareConsolidateOutputsMethod(methods.PrepareConsolidateOutputsMethodData{
+		Params: params,
+	}))
+}
+
+// ConsolidateOutputsDryRun reports which outputs ConsolidateOutputs would merge as of the last sync, without sending anything.
+// Without OutputThreshold, the threshold of the wallet's secret manager is used, which is lower for Ledger wallets.
+func (a *Account) ConsolidateOutputsDryRun(params types.ConsolidationParams) (*types.ConsolidationPreview, error) {
+	if params.OutputThreshold == 0 {
+		params.OutputThreshold = a.wallet.outputConsolidationThreshold
+	}
+
+	details, err := a.Details()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := a.UnspentOutputs(&types.FilterOptions{
+		OutputTypes: []types.OutputType{types.OutputTypeBasic},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := a.Balance()
+	if err != nil {
+		return nil, err
+	}
+
+	requiredBasicStorageDeposit, ok := new(big.Int).SetString(balance.RequiredStorageDeposit.Basic, 10)
+	if !ok {
+		requiredBasicStorageDeposit = nil
+	}
+
+	return types.NewConsolidationPreview(params, outputs, details.LockedOutputs, len(outputs), requiredBasicStorageDeposit), nil
+}
+
+// ConsolidateWhenRequired consolidates the outputs of the account whenever the wallet emits a ConsolidationRequired
+// event for it, e.g. when a transaction needs more inputs than allowed. onResult is called with the outcome and may be nil.
+func (a *Account) ConsolidateWhenRequired(params types.ConsolidationParams, onResult func(*types.Transaction, error)) error {
+	return a.wallet.Listen([]types.WalletEventType{types.WalletEventTypeConsolidationRequired}, func(event types.WalletEvent) {
+		if event.AccountIndex != a.index {
+			return
+		}
+
+		// The event is emitted from within a wallet call, so the consolidation must not block it
+		go func() {
+			transaction, err := a.ConsolidateOutputs(params)
+			if onResult != nil {
+				onResult(transaction, err)
+			}
+		}()
+	})
+}
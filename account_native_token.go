@@ -0,0 +1,127 @@
+package wasp_wallet_sdk
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// CreateNativeToken creates a foundry and mints the circulating supply of its native token.
+// A foundry is controlled by an alias; if no alias is given and the account has none, one is created first
+// and ctx bounds waiting for its inclusion.
+func (a *Account) CreateNativeToken(ctx context.Context, params types.CreateNativeTokenParams, options *types.TransactionOptions) (*types.CreateNativeTokenTransaction, error) {
+	mintParams, err := params.MintNativeTokenParams()
+	if err != nil {
+		return nil, err
+	}
+
+	if mintParams.AliasId == "" {
+		if err := a.ensureAlias(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	prepared, err := callAccountMethodWithContext[types.PreparedMintTokenTransactionData](ctx, a, methods.PrepareMintNativeTokenMethod(methods.PrepareMintNativeTokenMethodData{
+		Params:  mintParams,
+		Options: options,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := a.signAndSubmit(prepared.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CreateNativeTokenTransaction{
+		TokenId:     prepared.TokenId,
+		Transaction: *transaction,
+	}, nil
+}
+
+// MintNativeToken increases the circulating supply of a native token controlled by the account
+func (a *Account) MintNativeToken(tokenId types.HexEncodedString, amount *big.Int, options *types.TransactionOptions) (*types.Transaction, error) {
+	mintAmount, err := types.NewHexEncodedAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.prepareAndSubmit(methods.PrepareIncreaseNativeTokenSupplyMethod(methods.PrepareIncreaseNativeTokenSupplyMethodData{
+		TokenId:    tokenId,
+		MintAmount: mintAmount,
+		Options:    options,
+	}))
+}
+
+// MeltNativeToken decreases the circulating supply of a native token controlled by the account.
+// Melted tokens are accounted for in the foundry, unlike burned tokens.
+func (a *Account) MeltNativeToken(tokenId types.HexEncodedString, amount *big.Int, options *types.TransactionOptions) (*types.Transaction, error) {
+	meltAmount, err := types.NewHexEncodedAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.prepareAndSubmit(methods.PrepareDecreaseNativeTokenSupplyMethod(methods.PrepareDecreaseNativeTokenSupplyMethodData{
+		TokenId:    tokenId,
+		MeltAmount: meltAmount,
+		Options:    options,
+	}))
+}
+
+// BurnNativeToken destroys native tokens owned by the account, the foundry doesn't need to be controlled by the account
+func (a *Account) BurnNativeToken(tokenId types.HexEncodedString, amount *big.Int, options *types.TransactionOptions) (*types.Transaction, error) {
+	burnAmount, err := types.NewHexEncodedAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.prepareAndSubmit(methods.PrepareBurnMethod(methods.PrepareBurnMethodData{
+		Burn: types.Burn{
+			NativeTokens: types.BurnNativeTokens{tokenId: burnAmount},
+		},
+		Options: options,
+	}))
+}
+
+// DestroyFoundry destroys a foundry controlled by the account, its circulating supply has to be melted first
+func (a *Account) DestroyFoundry(foundryId types.HexEncodedString, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareBurnMethod(methods.PrepareBurnMethodData{
+		Burn: types.Burn{
+			Foundries: []types.HexEncodedString{foundryId},
+		},
+		Options: options,
+	}))
+}
+
+// NativeTokenBalances returns the native token balances as of the last sync.
+// The IRC30 metadata is only available for tokens whose foundries were synced, see SyncOptions.SyncNativeTokenFoundries.
+func (a *Account) NativeTokenBalances() ([]types.NativeTokenBalance, error) {
+	balance, err := a.Balance()
+	if err != nil {
+		return nil, err
+	}
+
+	return balance.NativeTokens, nil
+}
+
+// ensureAlias creates an alias output if the account has none and waits until it can be used
+func (a *Account) ensureAlias(ctx context.Context) error {
+	aliases, err := a.aliasOutputs()
+	if err != nil {
+		return err
+	}
+
+	if len(aliases) > 0 {
+		return nil
+	}
+
+	transaction, err := a.CreateAliasOutput(nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return a.waitForInclusion(ctx, transaction)
+}
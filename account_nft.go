@@ -0,0 +1,54 @@
+package wasp_wallet_sdk
+
+import (
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// MintNfts mints one NFT per params entry in a single transaction
+func (a *Account) MintNfts(params []types.MintNftParams, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareMintNftsMethod(methods.PrepareMintNftsMethodData{
+		Params:  params,
+		Options: options,
+	}))
+}
+
+// SendNft sends NFTs of the account to other addresses in a single transaction
+func (a *Account) SendNft(params []types.SendNftParams, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareSendNftMethod(methods.PrepareSendNftMethodData{
+		Params:  params,
+		Options: options,
+	}))
+}
+
+// BurnNft destroys an NFT of the account, its storage deposit is returned to the account
+func (a *Account) BurnNft(nftId types.HexEncodedString, options *types.TransactionOptions) (*types.Transaction, error) {
+	return a.prepareAndSubmit(methods.PrepareBurnMethod(methods.PrepareBurnMethodData{
+		Burn: types.Burn{
+			Nfts: []types.HexEncodedString{nftId},
+		},
+		Options: options,
+	}))
+}
+
+// Nfts returns the NFTs owned by the account as of the last sync, with their IRC27 metadata decoded if available
+func (a *Account) Nfts() ([]types.Nft, error) {
+	outputs, err := a.UnspentOutputs(&types.FilterOptions{
+		OutputTypes: []types.OutputType{types.OutputTypeNft},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nfts := make([]types.Nft, 0, len(outputs))
+	for _, output := range outputs {
+		nft, err := types.NewNft(output)
+		if err != nil {
+			return nil, err
+		}
+
+		nfts = append(nfts, *nft)
+	}
+
+	return nfts, nil
+}
@@ -0,0 +1,71 @@
+package wasp_wallet_sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// Transactions returns all transactions sent by the account
+func (a *Account) Transactions() ([]types.Transaction, error) {
+	return a.transactions(methods.TransactionsMethod())
+}
+
+// IncomingTransactions returns the transactions received by the account, only known if SyncOptions.SyncIncomingTransactions was set
+func (a *Account) IncomingTransactions() ([]types.Transaction, error) {
+	return a.transactions(methods.IncomingTransactionsMethod())
+}
+
+// PendingTransactions returns the transactions sent by the account which are not confirmed yet
+func (a *Account) PendingTransactions() ([]types.Transaction, error) {
+	return a.transactions(methods.PendingTransactionsMethod())
+}
+
+// GetTransaction returns a transaction sent or received by the account
+func (a *Account) GetTransaction(transactionId types.HexEncodedString) (*types.Transaction, error) {
+	for _, method := range []types.BaseCallAccountMethodWrap[any]{
+		methods.GetTransactionMethod(methods.GetTransactionMethodData{TransactionId: transactionId}),
+		methods.GetIncomingTransactionMethod(methods.GetTransactionMethodData{TransactionId: transactionId}),
+	} {
+		transaction, err := callAccountMethod[*types.Transaction](a, method)
+		if err != nil {
+			return nil, err
+		}
+
+		if *transaction != nil {
+			return *transaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionId)
+}
+
+// RetryTransactionUntilIncluded reattaches or promotes the transaction until it's included and returns the ID of the including block.
+// A zero interval or maxAttempts uses the SDK defaults; the interval is rounded to seconds.
+func (a *Account) RetryTransactionUntilIncluded(ctx context.Context, transactionId types.HexEncodedString, interval time.Duration, maxAttempts uint64) (types.HexEncodedString, error) {
+	blockId, err := callAccountMethodWithContext[types.HexEncodedString](ctx, a, methods.RetryTransactionUntilIncludedMethod(methods.RetryTransactionUntilIncludedMethodData{
+		Interval:      uint64(interval.Round(time.Second) / time.Second),
+		MaxAttempts:   maxAttempts,
+		TransactionId: transactionId,
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	return *blockId, nil
+}
+
+func (a *Account) transactions(method types.BaseCallAccountMethodWrap[any]) ([]types.Transaction, error) {
+	transactions, err := callAccountMethod[[]types.Transaction](a, method)
+	if err != nil {
+		return nil, err
+	}
+
+	return *transactions, nil
+}
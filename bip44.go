@@ -3,10 +3,10 @@ package wasp_wallet_sdk
 import "github.com/iotaledger/wasp-wallet-sdk/types"
 
 func BuildBip44Chain(coinType types.CoinType, accountIndex uint32, addressIndex uint32) types.Bip44Chain {
-	return types.Bip44Chain{
-		CoinType:     uint32(coinType),
-		Account:      accountIndex,
-		Change:       uint32(0),
-		AddressIndex: addressIndex,
-	}
+	return types.NewBip44Chain(coinType, accountIndex, addressIndex, false)
+}
+
+// BuildInternalBip44Chain returns the chain of an internal (change) address
+func BuildInternalBip44Chain(coinType types.CoinType, accountIndex uint32, addressIndex uint32) types.Bip44Chain {
+	return types.NewBip44Chain(coinType, accountIndex, addressIndex, true)
 }
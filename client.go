@@ -0,0 +1,197 @@
+package wasp_wallet_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+var ErrBlockConflicting = errors.New("block is conflicting")
+
+// DefaultInclusionPollInterval is used by WaitForBlockInclusion if no interval is given
+const DefaultInclusionPollInterval = time.Second
+
+type Client struct {
+	sdk       *IOTASDK
+	clientPtr IotaClientPtr
+	handle    *nativeHandle
+	closed    atomic.Bool
+}
+
+// NewClient creates a standalone client. Proof of work is done locally if ClientOptions.LocalPow is set,
+// using ClientOptions.PowWorkerCount threads.
+func NewClient(sdk *IOTASDK, clientOptions types.ClientOptions) (*Client, error) {
+	clientPtr, err := sdk.CreateClient(clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(sdk, sdk.handles.lookup(HandleKindClient, uintptr(clientPtr))), nil
+}
+
+func newClient(sdk *IOTASDK, handle *nativeHandle) *Client {
+	client := &Client{
+		sdk:       sdk,
+		clientPtr: IotaClientPtr(handle.key.ptr),
+		handle:    handle,
+	}
+
+	watchLeak(sdk.handles, client, handle)
+
+	return client
+}
+
+// Client returns the client of the wallet, which uses the client options of the wallet.
+// The client has to be closed; the native client stays alive until both the wallet and the client are closed.
+func (s *Wallet) Client() (*Client, error) {
+	if err := requireCapability(s.sdk.capabilities.Client, "call_client_method"); err != nil {
+		return nil, err
+	}
+
+	handle := s.sdk.handles.lookup(HandleKindClient, uintptr(s.clientPtr))
+	if s.clientPtr == 0 || handle == nil || s.closed.Load() {
+		return nil, fmt.Errorf("%w: %s", ErrHandleClosed, HandleKindClient)
+	}
+
+	if err := s.sdk.handles.retain(handle); err != nil {
+		return nil, err
+	}
+
+	return newClient(s.sdk, handle), nil
+}
+
+// Close releases the client, it's destroyed once it has no owner left and no call is running on it.
+// Closing it again is a no-op, other calls fail with ErrHandleClosed afterwards.
+func (c *Client) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+
+	return c.sdk.releaseTrackedHandle(c.handle)
+}
+
+// Destroy is Close without reporting errors
+func (c *Client) Destroy() {
+	_ = c.Close()
+}
+
+// callMethodContext calls a method of the native client, which is retained during the call
+func (c *Client) callMethodContext(ctx context.Context, method any) ([]byte, func(), error) {
+	if c.closed.Load() {
+		return nil, func() {}, fmt.Errorf("%w: %s", ErrHandleClosed, HandleKindClient)
+	}
+
+	release, err := c.sdk.acquireHandle(HandleKindClient, c.handle)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	defer release()
+
+	return c.sdk.CallClientMethodContext(ctx, c.clientPtr, method)
+}
+
+// BuildAndPostBlock posts a block with a tagged data payload and returns its ID.
+// Proof of work is done according to the client options, which is why this can take a while.
+// The context is only checked before the block is built, so a posted block's ID is always returned.
+func (c *Client) BuildAndPostBlock(ctx context.Context, payload types.TaggedDataPayload) (types.HexEncodedString, error) {
+	response, free, err := awaitCall(ctx, func() ([]byte, func(), error) {
+		return c.callMethodContext(ctx, methods.BuildAndPostBlockMethod(methods.BuildAndPostBlockMethodData{
+			Options: &types.BuildBlockOptions{
+				Tag:  payload.Tag,
+				Data: payload.Data,
+			},
+		}))
+	})
+	defer free()
+	if err != nil {
+		return "", err
+	}
+
+	// The response is a tuple of the block ID and the block
+	blockIdWithBlock, err := methods.ParseResponse[[]json.RawMessage](response, err)
+	if err != nil {
+		return "", err
+	}
+
+	if len(*blockIdWithBlock) == 0 {
+		return "", errors.New("missing block id in response")
+	}
+
+	var blockId types.HexEncodedString
+	if err := json.Unmarshal((*blockIdWithBlock)[0], &blockId); err != nil {
+		return "", err
+	}
+
+	return blockId, nil
+}
+
+// PostBlockRaw posts a serialized block as is and returns its ID.
+// Posting the same block again is harmless, so a done context returns early while the block might still get posted.
+func (c *Client) PostBlockRaw(ctx context.Context, blockBytes []byte) (types.HexEncodedString, error) {
+	response, free, err := callWithContext(ctx, func() ([]byte, func(), error) {
+		return c.callMethodContext(ctx, methods.PostBlockRawMethod(methods.PostBlockRawMethodData{
+			BlockBytes: blockBytes,
+		}))
+	})
+	defer free()
+	if err != nil {
+		return "", err
+	}
+
+	blockId, err := methods.ParseResponse[types.HexEncodedString](response, err)
+	if err != nil {
+		return "", err
+	}
+
+	return *blockId, nil
+}
+
+func (c *Client) GetBlockMetadata(blockId types.HexEncodedString) (*types.BlockMetadata, error) {
+	response, free, err := c.callMethodContext(context.Background(), methods.GetBlockMetadataMethod(methods.GetBlockMetadataMethodData{
+		BlockId: blockId,
+	}))
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[types.BlockMetadata](response, err)
+}
+
+// WaitForBlockInclusion polls the block metadata until a milestone referenced the block.
+// It returns ErrBlockConflicting if the block's transaction was not included; a zero pollInterval uses DefaultInclusionPollInterval.
+func (c *Client) WaitForBlockInclusion(ctx context.Context, blockId types.HexEncodedString, pollInterval time.Duration) (*types.BlockMetadata, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultInclusionPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		metadata, err := c.GetBlockMetadata(blockId)
+		if err != nil {
+			return nil, err
+		}
+
+		if metadata.IsReferenced() {
+			if metadata.LedgerInclusionState == types.LedgerInclusionStateConflicting {
+				return metadata, fmt.Errorf("%w: block %s, reason %d", ErrBlockConflicting, blockId, metadata.ConflictReason)
+			}
+
+			return metadata, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Command wasp-wallet inspects and operates Stronghold and Ledger wallets without writing Go.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/awnumar/memguard"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/config"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// errUsage is returned for invalid arguments, the usage was already printed
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	usage       string
+	description string
+	run         func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands map[string]command
+
+// init breaks the initialization cycle between the commands and their usage
+func init() {
+	commands = map[string]command{
+		"init":            {"init [-import]", "create a Stronghold snapshot with a new or imported mnemonic, or check a Ledger", runInit},
+		"mnemonic":        {"mnemonic generate|verify", "generate a mnemonic and print it to stderr, or verify a prompted one", runMnemonic},
+		"address":         {"address [-evm] [-account n] [-index n] [-range n] [-internal]", "print addresses", runAddress},
+		"sign-essence":    {"sign-essence [-account n] [-index n] [-internal] <hex message>", "sign a transaction essence with an Ed25519 key", runSignEssence},
+		"ledger":          {"ledger status", "print the status of the Ledger", runLedger},
+		"backup":          {"backup <destination>", "back up the wallet to a Stronghold snapshot", runBackup},
+		"restore":         {"restore [-ignore-coin-type] <source>", "restore the wallet from a backup", runRestore},
+		"change-password": {"change-password", "change the password of the Stronghold snapshot", runChangePassword},
+		"balance":         {"balance [-account n]", "sync the account and print its balance", runBalance},
+		"send":            {"send [-account n] [-wait] <address> <amount>", "send base coins, the amount is in base units", runSend},
+		"profile":         {"profile list|show|add|remove|switch [flags] [name]", "manage wallet profiles", runProfile},
+	}
+}
+
+// cli holds the global flags
+type cli struct {
+	libPath        string
+	strongholdPath string
+	storagePath    string
+	node           string
+	networkName    string
+	ledger         bool
+	emulator       bool
+	testnet        bool
+	jsonOutput     bool
+	profilesDir    string
+	profileName    string
+
+	// Set by the profile
+	bech32HrpOverride string
+	clientOptions     *types.ClientOptions
+	passwordSource    string
+
+	// strongholdProfile is the profile of the Stronghold snapshot, it's checked once the snapshot is opened
+	strongholdProfile *config.Profile
+
+	stdout io.Writer
+	stderr io.Writer
+	prompt *prompter
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	c := &cli{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		prompt: newPrompter(os.Stdin, os.Stderr),
+	}
+
+	if err := c.run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	flags := newGlobalFlags(c)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return errUsage
+	}
+
+	c.ledger = c.ledger || c.emulator
+
+	if flags.Arg(0) != "profile" {
+		if err := c.applyProfile(flags); err != nil {
+			return err
+		}
+	}
+
+	return cmd.run(ctx, c, flags.Args()[1:])
+}
+
+// newGlobalFlags binds the global flags to the cli
+func newGlobalFlags(c *cli) *flag.FlagSet {
+	flags := flag.NewFlagSet("wasp-wallet", flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = c.usage(flags)
+
+	flags.StringVar(&c.libPath, "lib", "", "path of the native IOTA SDK library, found automatically if empty")
+	flags.StringVar(&c.strongholdPath, "stronghold", "wallet.stronghold", "path of the Stronghold snapshot")
+	flags.StringVar(&c.storagePath, "db", "wallet-db", "path of the wallet database")
+	flags.StringVar(&c.node, "node", "", "URL of the L1 node, required by wallet commands")
+	flags.StringVar(&c.networkName, "network", types.NetworkShimmer.Name, "network of the wallet")
+	flags.BoolVar(&c.ledger, "ledger", false, "use a Ledger instead of a Stronghold snapshot")
+	flags.BoolVar(&c.emulator, "emulator", false, "use the Speculos Ledger simulator")
+	flags.BoolVar(&c.testnet, "testnet", false, "use the testnet bech32 HRP of the network")
+	flags.BoolVar(&c.jsonOutput, "json", false, "print JSON instead of text")
+	flags.StringVar(&c.profilesDir, "profiles", "", "directory of the wallet profiles, defaults to the user config directory")
+	flags.StringVar(&c.profileName, "profile", "", "profile to use instead of the current one")
+
+	return flags
+}
+
+func (c *cli) usage(flags *flag.FlagSet) func() {
+	return func() {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet [flags] <command> [arguments]")
+		fmt.Fprintln(c.stderr, "\ncommands:")
+
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Fprintf(c.stderr, "  %-64s %s\n", commands[name].usage, commands[name].description)
+		}
+
+		fmt.Fprintln(c.stderr, "\nflags:")
+		flags.PrintDefaults()
+	}
+}
+
+func (c *cli) network() (types.Network, error) {
+	return types.NetworkByName(c.networkName)
+}
+
+// bech32Hrp returns the HRP of the selected network, depending on -testnet or the profile
+func (c *cli) bech32Hrp() (string, error) {
+	if c.bech32HrpOverride != "" {
+		return c.bech32HrpOverride, nil
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return "", err
+	}
+
+	if c.testnet {
+		return network.TestnetBech32Hrp, nil
+	}
+
+	return network.Bech32Hrp, nil
+}
+
+func (c *cli) loadSDK() (*wasp_wallet_sdk.IOTASDK, error) {
+	if c.libPath == "" {
+		return wasp_wallet_sdk.NewIotaSDKFromEnv()
+	}
+
+	return wasp_wallet_sdk.NewIotaSDK(c.libPath)
+}
+
+// strongholdPassword resolves the password source of the profile, or prompts for the password
+func (c *cli) strongholdPassword() (*memguard.Enclave, error) {
+	if c.passwordSource != "" {
+		return config.ResolveSecret(c.passwordSource)
+	}
+
+	return c.prompt.secret("Stronghold password")
+}
+
+// commandFlags creates the flag set of a command, which prints the command usage on errors
+func (c *cli) commandFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet [flags]", commands[name].usage)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+// parseCommandFlags parses the flags of a command and checks the number of positional arguments
+func (c *cli) parseCommandFlags(flags *flag.FlagSet, args []string, positional int) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if flags.NArg() != positional {
+		flags.Usage()
+		return errUsage
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/config"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// newTestCLI returns a cli writing into buffers, the prompt reads the input
+func newTestCLI(t *testing.T, input string) (*cli, *bytes.Buffer, *bytes.Buffer) {
+	in, err := os.CreateTemp(t.TempDir(), "stdin")
+	require.NoError(t, err)
+	t.Cleanup(func() { in.Close() })
+
+	_, err = in.WriteString(input)
+	require.NoError(t, err)
+	_, err = in.Seek(0, 0)
+	require.NoError(t, err)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	return &cli{stdout: stdout, stderr: stderr, prompt: newPrompter(in, stderr)}, stdout, stderr
+}
+
+// runCLI runs the command line with a profile directory of the test, so the user's profiles are never touched
+func runCLI(t *testing.T, profilesDir string, args ...string) (string, string, error) {
+	c, stdout, stderr := newTestCLI(t, "")
+	err := c.run(context.Background(), append([]string{"-profiles", profilesDir}, args...))
+
+	return stdout.String(), stderr.String(), err
+}
+
+func TestUsage(t *testing.T) {
+	dir := t.TempDir()
+
+	_, stderr, err := runCLI(t, dir)
+	require.ErrorIs(t, err, errUsage)
+	require.Contains(t, stderr, "usage: wasp-wallet [flags] <command> [arguments]")
+	require.Contains(t, stderr, commands["restore"].usage)
+
+	_, stderr, err = runCLI(t, dir, "unknown")
+	require.ErrorIs(t, err, errUsage)
+	require.Contains(t, stderr, `unknown command "unknown"`)
+
+	_, _, err = runCLI(t, dir, "-unknown-flag", "address")
+	require.ErrorIs(t, err, errUsage)
+
+	_, _, err = runCLI(t, dir, "-h")
+	require.NoError(t, err)
+
+	// Arguments are checked before the native library is loaded
+	for _, args := range [][]string{
+		{"mnemonic"},
+		{"mnemonic", "print"},
+		{"ledger", "unlock"},
+		{"profile"},
+		{"profile", "rename"},
+		{"profile", "remove"},
+		{"sign-essence"},
+		{"backup"},
+		{"send", "smr1qq"},
+		{"address", "extra"},
+	} {
+		_, stderr, err := runCLI(t, dir, args...)
+		require.ErrorIs(t, err, errUsage, args)
+		require.Contains(t, stderr, "usage: wasp-wallet", args)
+	}
+}
+
+func TestOpenMissingSnapshot(t *testing.T) {
+	// Stronghold would create the snapshot, opening the wallet has to fail before
+	c, _, _ := newTestCLI(t, "")
+	flags := newGlobalFlags(c)
+	require.NoError(t, flags.Parse([]string{"-node", "http://localhost:14265", "-stronghold", filepath.Join(t.TempDir(), "missing.stronghold")}))
+
+	_, _, err := c.openWallet(nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorContains(t, err, "create it with init")
+
+	_, err = c.openSecretManager(nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAddressRangeOverflow(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, args := range [][]string{
+		{"address", "-index", "4294967295", "-range", "1"},
+		{"address", "-index", "4294967296", "-range", "0"},
+		{"address", "-range", "4294967296"},
+		{"address", "-account", "4294967296"},
+	} {
+		_, stderr, err := runCLI(t, dir, args...)
+		require.ErrorIs(t, err, errUsage, args)
+		require.Contains(t, stderr, "must not exceed 4294967295", args)
+	}
+}
+
+func TestProfileCommands(t *testing.T) {
+	dir := t.TempDir()
+
+	stdout, _, err := runCLI(t, dir, "profile", "add", "-network", "iota", "-db", "iota-db", "-node", "http://localhost:14265", "-stronghold", "iota.snap", "-password-source", "env:IOTA_PASSWORD", "iota")
+	require.NoError(t, err)
+	require.Equal(t, "profile:  iota\n", stdout)
+
+	stdout, _, err = runCLI(t, dir, "-json", "profile", "add", "-ledger", "-emulator", "-hrp", "rms", "-db", "ledger-db", "-node", "http://localhost:14265", "ledger")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"profile": "ledger"}`, stdout)
+
+	_, _, err = runCLI(t, dir, "profile", "add", "-unknown", "other")
+	require.ErrorIs(t, err, errUsage)
+
+	_, _, err = runCLI(t, dir, "profile", "switch", "iota")
+	require.NoError(t, err)
+
+	stdout, _, err = runCLI(t, dir, "profile", "list")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "* iota"))
+	require.Contains(t, lines[0], "stronghold:"+filepath.Join(mustAbs(t, "."), "iota.snap"))
+	require.True(t, strings.HasPrefix(lines[1], "  ledger"))
+	require.Contains(t, lines[1], "ledger:emulator")
+
+	stdout, _, err = runCLI(t, dir, "profile", "switch", "ledger")
+	require.NoError(t, err)
+	require.Equal(t, "profile:  ledger\n", stdout)
+
+	stdout, _, err = runCLI(t, dir, "-json", "profile", "show")
+	require.NoError(t, err)
+
+	var entry profileEntry
+	require.NoError(t, json.Unmarshal([]byte(stdout), &entry))
+	require.Equal(t, profileEntry{
+		Name:          "ledger",
+		Current:       true,
+		Network:       "shimmer",
+		Bech32Hrp:     "rms",
+		StoragePath:   filepath.Join(mustAbs(t, "."), "ledger-db"),
+		SecretManager: "ledger:emulator",
+	}, entry)
+
+	stdout, _, err = runCLI(t, dir, "profile", "show", "iota")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "current:        false\n")
+	require.Contains(t, stdout, "network:        iota\n")
+	require.NotContains(t, stdout, "bech32Hrp")
+
+	_, _, err = runCLI(t, dir, "profile", "show", "missing")
+	require.Error(t, err)
+
+	stdout, _, err = runCLI(t, dir, "profile", "remove", "iota")
+	require.NoError(t, err)
+	require.Equal(t, "profile:  iota\n", stdout)
+
+	stdout, _, err = runCLI(t, dir, "-json", "profile", "list")
+	require.NoError(t, err)
+
+	var entries []profileEntry
+	require.NoError(t, json.Unmarshal([]byte(stdout), &entries))
+	require.Len(t, entries, 1)
+	require.Equal(t, "ledger", entries[0].Name)
+}
+
+func TestProfileApply(t *testing.T) {
+	dir := t.TempDir()
+
+	_, _, err := runCLI(t, dir, "profile", "add", "-network", "iota", "-hrp", "atoi", "-db", "iota-db", "-node", "http://localhost:14265", "-ledger", "iota")
+	require.NoError(t, err)
+
+	c, _, _ := newTestCLI(t, "")
+	flags := newGlobalFlags(c)
+	require.NoError(t, flags.Parse([]string{"-profiles", dir, "-profile", "iota", "-network", "shimmer"}))
+	require.NoError(t, c.applyProfile(flags))
+
+	// Explicit flags take precedence over the profile
+	require.Equal(t, "shimmer", c.networkName)
+	require.Equal(t, filepath.Join(mustAbs(t, "."), "iota-db"), c.storagePath)
+	require.Equal(t, []any{"http://localhost:14265"}, c.clientOptions.Nodes)
+	require.True(t, c.ledger)
+
+	hrp, err := c.bech32Hrp()
+	require.NoError(t, err)
+	require.Equal(t, "atoi", hrp)
+
+	// A Stronghold profile without nodes and password source takes -node and prompts, its first address is recorded once it's opened
+	snapshotPath := filepath.Join(t.TempDir(), "wallet.stronghold")
+	_, _, err = runCLI(t, dir, "profile", "add", "-db", "shimmer-db", "-stronghold", snapshotPath, "shimmer")
+	require.NoError(t, err)
+
+	c, _, _ = newTestCLI(t, "")
+	flags = newGlobalFlags(c)
+	require.NoError(t, flags.Parse([]string{"-profiles", dir, "-profile", "shimmer"}))
+	require.NoError(t, c.applyProfile(flags))
+
+	require.Nil(t, c.clientOptions)
+	require.Empty(t, c.passwordSource)
+	require.NotNil(t, c.strongholdProfile)
+	require.Empty(t, c.strongholdProfile.Address)
+
+	require.NoError(t, c.checkStronghold(fixedAddress("smr1first")))
+	profiles, err := c.profiles()
+	require.NoError(t, err)
+	shimmer, err := profiles.Get("shimmer")
+	require.NoError(t, err)
+	require.Equal(t, "smr1first", shimmer.Address)
+
+	require.NoError(t, c.checkStronghold(fixedAddress("smr1first")))
+	require.ErrorIs(t, c.checkStronghold(fixedAddress("smr1other")), config.ErrAddressMismatch)
+}
+
+// fixedAddress is a secret manager with a single address
+type fixedAddress string
+
+func (a fixedAddress) GenerateEd25519Address(uint32, uint32, string, types.CoinType, *types.IGenerateAddressOptions) (string, error) {
+	return string(a), nil
+}
+
+func TestPromptPipedSecret(t *testing.T) {
+	c, _, stderr := newTestCLI(t, "secret\nsecret\n")
+
+	secret, err := c.prompt.newSecret("Stronghold password")
+	require.NoError(t, err)
+
+	buffer, err := secret.Open()
+	require.NoError(t, err)
+	require.Equal(t, "secret", buffer.String())
+	buffer.Destroy()
+	require.Equal(t, "Stronghold password: Repeat Stronghold password: ", stderr.String())
+
+	c, _, _ = newTestCLI(t, "one\ntwo\n")
+	_, err = c.prompt.newSecret("Stronghold password")
+	require.ErrorIs(t, err, errSecretMismatch)
+
+	c, _, _ = newTestCLI(t, "")
+	_, err = c.prompt.secret("Mnemonic")
+	require.Error(t, err)
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "1.5 SMR", formatAmount(types.NetworkShimmer, "1500000"))
+	require.Equal(t, "0 IOTA", formatAmount(types.NetworkIOTA, "0"))
+	require.Equal(t, "not a number", formatAmount(types.NetworkShimmer, "not a number"))
+
+	c, stdout, _ := newTestCLI(t, "")
+	require.NoError(t, c.printFields([][2]string{{"available", formatAmount(types.NetworkShimmer, new(big.Int).SetUint64(2_000_000).String())}}))
+	require.Equal(t, "available:  2 SMR\n", stdout.String())
+}
+
+func mustAbs(t *testing.T, path string) string {
+	abs, err := filepath.Abs(path)
+	require.NoError(t, err)
+
+	return abs
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// print writes the value as JSON in JSON mode, otherwise the text function formats it
+func (c *cli) print(value any, text func(w io.Writer)) error {
+	if c.jsonOutput {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	text(w)
+
+	return w.Flush()
+}
+
+// printFields prints key/value pairs, in JSON mode as object
+func (c *cli) printFields(fields [][2]string) error {
+	value := make(map[string]string, len(fields))
+	for _, field := range fields {
+		value[field[0]] = field[1]
+	}
+
+	return c.print(value, func(w io.Writer) {
+		for _, field := range fields {
+			fmt.Fprintf(w, "%s:\t%s\n", field[0], field[1])
+		}
+	})
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/iotaledger/wasp-wallet-sdk/config"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type profileEntry struct {
+	Name          string `json:"name"`
+	Current       bool   `json:"current"`
+	Network       string `json:"network"`
+	Bech32Hrp     string `json:"bech32Hrp,omitempty"`
+	StoragePath   string `json:"storagePath"`
+	SecretManager string `json:"secretManager"`
+}
+
+func newProfileEntry(profile config.Profile, current bool) profileEntry {
+	secretManager := string(profile.SecretManager.Type)
+	switch profile.SecretManager.Type {
+	case config.SecretManagerStronghold:
+		secretManager += ":" + profile.SecretManager.SnapshotPath
+	case config.SecretManagerLedger:
+		if profile.SecretManager.Emulator {
+			secretManager += ":emulator"
+		}
+	}
+
+	return profileEntry{
+		Name:          profile.Name,
+		Current:       current,
+		Network:       profile.Network,
+		Bech32Hrp:     profile.Bech32Hrp,
+		StoragePath:   profile.StoragePath,
+		SecretManager: secretManager,
+	}
+}
+
+func runProfile(_ context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet", commands["profile"].usage)
+		return errUsage
+	}
+
+	profiles, err := c.profiles()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		return c.listProfiles(profiles)
+	case "show":
+		return c.showProfile(profiles, args[1:])
+	case "add":
+		return c.addProfile(profiles, args[1:])
+	case "remove", "switch":
+		if len(args) != 2 {
+			fmt.Fprintln(c.stderr, "usage: wasp-wallet profile", args[0], "<name>")
+			return errUsage
+		}
+
+		if args[0] == "remove" {
+			err = profiles.Remove(args[1])
+		} else {
+			err = profiles.Switch(args[1])
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.printFields([][2]string{{"profile", args[1]}})
+	default:
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet", commands["profile"].usage)
+		return errUsage
+	}
+}
+
+func (c *cli) listProfiles(profiles *config.Profiles) error {
+	list, err := profiles.List()
+	if err != nil {
+		return err
+	}
+
+	currentName := ""
+	if current, err := profiles.Current(); err == nil {
+		currentName = current.Name
+	}
+
+	entries := make([]profileEntry, len(list))
+	for i, profile := range list {
+		entries[i] = newProfileEntry(profile, profile.Name == currentName)
+	}
+
+	return c.print(entries, func(w io.Writer) {
+		for _, entry := range entries {
+			marker := " "
+			if entry.Current {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\n", marker, entry.Name, entry.Network, entry.SecretManager, entry.StoragePath)
+		}
+	})
+}
+
+func (c *cli) showProfile(profiles *config.Profiles, args []string) error {
+	var profile *config.Profile
+	var err error
+	switch len(args) {
+	case 0:
+		profile, err = profiles.Current()
+	case 1:
+		profile, err = profiles.Get(args[0])
+	default:
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet profile show [name]")
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	entry := newProfileEntry(*profile, false)
+	if current, err := profiles.Current(); err == nil {
+		entry.Current = current.Name == profile.Name
+	}
+
+	return c.print(entry, func(w io.Writer) {
+		fmt.Fprintf(w, "name:\t%s\n", entry.Name)
+		fmt.Fprintf(w, "current:\t%t\n", entry.Current)
+		fmt.Fprintf(w, "network:\t%s\n", entry.Network)
+		if entry.Bech32Hrp != "" {
+			fmt.Fprintf(w, "bech32Hrp:\t%s\n", entry.Bech32Hrp)
+		}
+		fmt.Fprintf(w, "storagePath:\t%s\n", entry.StoragePath)
+		fmt.Fprintf(w, "secretManager:\t%s\n", entry.SecretManager)
+	})
+}
+
+func (c *cli) addProfile(profiles *config.Profiles, args []string) error {
+	flags := flag.NewFlagSet("profile add", flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet profile add [flags] <name>")
+		flags.PrintDefaults()
+	}
+
+	networkName := flags.String("network", types.NetworkShimmer.Name, "network of the profile")
+	coinType := flags.Uint("coin-type", 0, "coin type of a private network, it's registered under the name of -network with the HRP of -hrp")
+	hrp := flags.String("hrp", "", "bech32 HRP, defaults to the HRP of the network")
+	node := flags.String("node", "", "URL of the L1 node, otherwise wallet commands need -node")
+	storagePath := flags.String("db", "", "path of the wallet database")
+	strongholdPath := flags.String("stronghold", "", "path of the Stronghold snapshot")
+	passwordSource := flags.String("password-source", "", "source of the Stronghold password, env:NAME or file:PATH, otherwise it's prompted")
+	ledger := flags.Bool("ledger", false, "use a Ledger instead of a Stronghold snapshot")
+	emulator := flags.Bool("emulator", false, "use the Speculos Ledger simulator")
+	if err := c.parseCommandFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	profile := config.Profile{
+		Name:      flags.Arg(0),
+		Network:   *networkName,
+		CoinType:  types.CoinType(*coinType),
+		Bech32Hrp: *hrp,
+	}
+
+	// Paths are stored absolute, so the profile can be used from any directory
+	var err error
+	if *storagePath != "" {
+		if profile.StoragePath, err = filepath.Abs(*storagePath); err != nil {
+			return err
+		}
+	}
+
+	if *node != "" {
+		profile.ClientOptions = &types.ClientOptions{Nodes: []any{*node}}
+	}
+
+	if *ledger || *emulator {
+		profile.SecretManager = config.SecretManagerConfig{Type: config.SecretManagerLedger, Emulator: *emulator}
+	} else {
+		profile.SecretManager = config.SecretManagerConfig{Type: config.SecretManagerStronghold, PasswordSource: *passwordSource}
+		if *strongholdPath != "" {
+			if profile.SecretManager.SnapshotPath, err = filepath.Abs(*strongholdPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := profiles.Add(profile); err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{{"profile", profile.Name}})
+}
+
+func (c *cli) profiles() (*config.Profiles, error) {
+	if c.profilesDir != "" {
+		return config.NewProfiles(c.profilesDir), nil
+	}
+
+	dir, err := config.DefaultProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return config.NewProfiles(dir), nil
+}
+
+// applyProfile takes the settings of the selected or current profile, flags set explicitly take precedence.
+// A Stronghold snapshot used through a profile is checked against the first address of the profile once it's opened.
+func (c *cli) applyProfile(flags *flag.FlagSet) error {
+	profiles, err := c.profiles()
+	if err != nil {
+		return err
+	}
+
+	var profile *config.Profile
+	if c.profileName != "" {
+		profile, err = profiles.Get(c.profileName)
+	} else if profile, err = profiles.Current(); errors.Is(err, config.ErrNoCurrentProfile) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if !set["network"] {
+		c.networkName = profile.Network
+	}
+	if !set["testnet"] {
+		c.bech32HrpOverride = profile.Bech32Hrp
+	}
+	if !set["db"] {
+		c.storagePath = profile.StoragePath
+	}
+	if !set["node"] && profile.ClientOptions != nil && len(profile.ClientOptions.Nodes) > 0 {
+		c.clientOptions = profile.ClientOptions
+	}
+
+	if !set["ledger"] && !set["emulator"] && !set["stronghold"] {
+		switch profile.SecretManager.Type {
+		case config.SecretManagerStronghold:
+			c.strongholdPath = profile.SecretManager.SnapshotPath
+			c.passwordSource = profile.SecretManager.PasswordSource
+		case config.SecretManagerLedger:
+			c.ledger = true
+			c.emulator = profile.SecretManager.Emulator
+		default:
+			return fmt.Errorf("profile %s: secret manager %q is not supported by wasp-wallet", profile.Name, profile.SecretManager.Type)
+		}
+	}
+
+	if !c.ledger {
+		c.strongholdProfile = profile
+	}
+
+	return nil
+}
+
+// checkStronghold checks the Stronghold snapshot of the profile against its first address, it's called once the snapshot was opened
+func (c *cli) checkStronghold(secretManager config.AddressGenerator) error {
+	if c.strongholdProfile == nil || c.ledger {
+		return nil
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := c.profiles()
+	if err != nil {
+		return err
+	}
+
+	return profiles.CheckStronghold(c.strongholdProfile, secretManager, network.CoinType)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/awnumar/memguard"
+	"golang.org/x/term"
+)
+
+var errSecretMismatch = errors.New("the entries don't match")
+
+// prompter reads secrets into memguard enclaves.
+// On a terminal the input isn't echoed, otherwise a line is read, so secrets can be piped in by scripts.
+type prompter struct {
+	in     *os.File
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+func newPrompter(in *os.File, out io.Writer) *prompter {
+	return &prompter{
+		in:     in,
+		reader: bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+func (p *prompter) secret(prompt string) (*memguard.Enclave, error) {
+	fmt.Fprint(p.out, prompt+": ")
+
+	var secret []byte
+	var err error
+	if fd := int(p.in.Fd()); term.IsTerminal(fd) {
+		secret, err = term.ReadPassword(fd)
+		fmt.Fprintln(p.out)
+	} else {
+		secret, err = p.readLine()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.New("no input")
+	}
+
+	// NewEnclave wipes the plain secret
+	return memguard.NewEnclave(secret), nil
+}
+
+// newSecret prompts twice, to catch typos in secrets which can't be recovered
+func (p *prompter) newSecret(prompt string) (*memguard.Enclave, error) {
+	secret, err := p.secret(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation, err := p.secret("Repeat " + prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	secretBuffer, err := secret.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer secretBuffer.Destroy()
+
+	confirmationBuffer, err := confirmation.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer confirmationBuffer.Destroy()
+
+	if !secretBuffer.EqualTo(confirmationBuffer.Bytes()) {
+		return nil, errSecretMismatch
+	}
+
+	return secret, nil
+}
+
+func (p *prompter) readLine() ([]byte, error) {
+	line, err := p.reader.ReadBytes('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimRight(line, "\r\n")
+	secret := append([]byte(nil), trimmed...)
+	memguard.WipeBytes(line)
+
+	return secret, nil
+}
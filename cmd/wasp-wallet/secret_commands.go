@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/awnumar/memguard"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type addressEntry struct {
+	Index   uint32 `json:"index"`
+	Address string `json:"address"`
+}
+
+func runInit(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("init")
+	importMnemonic := flags.Bool("import", false, "prompt for an existing mnemonic instead of generating one")
+	if err := c.parseCommandFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	if c.ledger {
+		secretManager, err := wasp_wallet_sdk.NewLedgerSecretManager(sdk, c.emulator)
+		if err != nil {
+			return err
+		}
+		defer secretManager.Destroy()
+
+		status, err := secretManager.GetLedgerStatus()
+		if err != nil {
+			return err
+		}
+
+		if !status.Connected || status.Locked {
+			return errors.New("ledger is not connected or locked")
+		}
+
+		return c.printInitialized("ledger", secretManager)
+	}
+
+	if _, err := os.Stat(c.strongholdPath); err == nil {
+		return fmt.Errorf("%s already exists", c.strongholdPath)
+	}
+
+	// The password of a profile comes from its password source, otherwise it's prompted twice
+	var password *memguard.Enclave
+	if c.passwordSource != "" {
+		password, err = c.strongholdPassword()
+	} else {
+		password, err = c.prompt.newSecret("Stronghold password")
+	}
+	if err != nil {
+		return err
+	}
+
+	var mnemonic *memguard.Enclave
+	if *importMnemonic {
+		if mnemonic, err = c.prompt.secret("Mnemonic"); err != nil {
+			return err
+		}
+		if err := sdk.Utils().VerifyMnemonic(mnemonic); err != nil {
+			return fmt.Errorf("invalid mnemonic: %w", err)
+		}
+	} else if mnemonic, err = sdk.Utils().GenerateMnemonic(); err != nil {
+		return err
+	}
+
+	secretManager, err := wasp_wallet_sdk.NewStrongholdSecretManager(sdk, password, c.strongholdPath)
+	if err != nil {
+		return err
+	}
+	defer secretManager.Destroy()
+
+	stored, err := secretManager.StoreMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return errors.New("failed to store the mnemonic")
+	}
+
+	if err := c.checkStronghold(secretManager); err != nil {
+		return err
+	}
+
+	// The generated mnemonic goes to stderr only, so it doesn't end up in captured output
+	if !*importMnemonic {
+		buffer, err := mnemonic.Open()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stderr, "Write down the mnemonic, it's the only way to recover the wallet:\n\n%s\n\n", buffer.String())
+		buffer.Destroy()
+	}
+
+	return c.printInitialized(c.strongholdPath, secretManager)
+}
+
+func (c *cli) printInitialized(location string, secretManager *wasp_wallet_sdk.SecretManager) error {
+	network, err := c.network()
+	if err != nil {
+		return err
+	}
+
+	hrp, err := c.bech32Hrp()
+	if err != nil {
+		return err
+	}
+
+	address, err := secretManager.GenerateEd25519Address(0, 0, hrp, network.CoinType, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{
+		{"secretManager", location},
+		{"address", address},
+	})
+}
+
+func runMnemonic(_ context.Context, c *cli, args []string) error {
+	if len(args) != 1 || (args[0] != "generate" && args[0] != "verify") {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet", commands["mnemonic"].usage)
+		return errUsage
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	if args[0] == "verify" {
+		mnemonic, err := c.prompt.secret("Mnemonic")
+		if err != nil {
+			return err
+		}
+
+		if err := sdk.Utils().VerifyMnemonic(mnemonic); err != nil {
+			return fmt.Errorf("invalid mnemonic: %w", err)
+		}
+
+		return c.printFields([][2]string{{"mnemonic", "valid"}})
+	}
+
+	mnemonic, err := sdk.Utils().GenerateMnemonic()
+	if err != nil {
+		return err
+	}
+
+	// The mnemonic goes to stderr only like the one of init, so it doesn't end up in captured output
+	buffer, err := mnemonic.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	fmt.Fprintln(c.stderr, buffer.String())
+
+	return nil
+}
+
+func runAddress(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("address")
+	evm := flags.Bool("evm", false, "print EVM addresses instead of Ed25519 addresses")
+	accountIndex := flags.Uint("account", 0, "account index")
+	addressIndex := flags.Uint("index", 0, "index of the first address")
+	count := flags.Uint("range", 1, "number of addresses")
+	internal := flags.Bool("internal", false, "print internal (change) addresses")
+	if err := c.parseCommandFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	// Address indices are uint32, the end of the range included
+	if *accountIndex > math.MaxUint32 || *addressIndex > math.MaxUint32 || *count > math.MaxUint32-*addressIndex {
+		fmt.Fprintf(c.stderr, "-account and -index + -range must not exceed %d\n", uint32(math.MaxUint32))
+		flags.Usage()
+		return errUsage
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return err
+	}
+
+	hrp, err := c.bech32Hrp()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	secretManager, err := c.openSecretManager(sdk)
+	if err != nil {
+		return err
+	}
+
+	addressRange := types.NewRange(uint32(*addressIndex), uint32(*addressIndex+*count))
+	options := &types.IGenerateAddressOptions{Internal: *internal}
+
+	var addresses []string
+	if *evm {
+		addresses, err = secretManager.GenerateEvmAddresses(addressRange, uint32(*accountIndex), hrp, options)
+	} else {
+		addresses, err = secretManager.GenerateEd25519Addresses(addressRange, uint32(*accountIndex), hrp, network.CoinType, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	entries := make([]addressEntry, len(addresses))
+	for i, address := range addresses {
+		entries[i] = addressEntry{Index: addressRange.Start + uint32(i), Address: address}
+	}
+
+	return c.print(entries, func(w io.Writer) {
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%d\t%s\n", entry.Index, entry.Address)
+		}
+	})
+}
+
+func runSignEssence(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("sign-essence")
+	accountIndex := flags.Uint("account", 0, "account index")
+	addressIndex := flags.Uint("index", 0, "address index")
+	internal := flags.Bool("internal", false, "sign with the key of an internal (change) address")
+	if err := c.parseCommandFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	essence := types.HexEncodedString(flags.Arg(0))
+	if _, err := essence.Bytes(); err != nil {
+		return fmt.Errorf("invalid essence: %w", err)
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	secretManager, err := c.openSecretManager(sdk)
+	if err != nil {
+		return err
+	}
+
+	bip44Chain := types.NewBip44Chain(network.CoinType, uint32(*accountIndex), uint32(*addressIndex), *internal)
+	signature, err := secretManager.SignTransactionEssence(essence, bip44Chain)
+	if err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{
+		{"chain", bip44Chain.String()},
+		{"publicKey", signature.PublicKey},
+		{"signature", signature.Signature},
+	})
+}
+
+func runLedger(_ context.Context, c *cli, args []string) error {
+	if len(args) != 1 || args[0] != "status" {
+		fmt.Fprintln(c.stderr, "usage: wasp-wallet", commands["ledger"].usage)
+		return errUsage
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	secretManager, err := wasp_wallet_sdk.NewLedgerSecretManager(sdk, c.emulator)
+	if err != nil {
+		return err
+	}
+
+	status, err := secretManager.GetLedgerStatus()
+	if err != nil {
+		return err
+	}
+
+	return c.print(status, func(w io.Writer) {
+		fmt.Fprintf(w, "connected:\t%t\n", status.Connected)
+		fmt.Fprintf(w, "locked:\t%t\n", status.Locked)
+		fmt.Fprintf(w, "blindSigningEnabled:\t%t\n", status.BlindSigningEnabled)
+		if status.App != nil {
+			fmt.Fprintf(w, "app:\t%s %s\n", status.App.Name, status.App.Version)
+		}
+	})
+}
+
+// openSecretManager opens the Ledger or the existing Stronghold snapshot, prompting for its password
+func (c *cli) openSecretManager(sdk *wasp_wallet_sdk.IOTASDK) (*wasp_wallet_sdk.SecretManager, error) {
+	if c.ledger {
+		return wasp_wallet_sdk.NewLedgerSecretManager(sdk, c.emulator)
+	}
+
+	if err := c.requireSnapshot(); err != nil {
+		return nil, err
+	}
+
+	password, err := c.strongholdPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	secretManager, err := wasp_wallet_sdk.NewStrongholdSecretManager(sdk, password, c.strongholdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkStronghold(secretManager); err != nil {
+		secretManager.Destroy()
+		return nil, err
+	}
+
+	return secretManager, nil
+}
+
+// requireSnapshot fails if the Stronghold snapshot doesn't exist, Stronghold would silently create a new one
+func (c *cli) requireSnapshot() error {
+	if _, err := os.Stat(c.strongholdPath); err != nil {
+		return fmt.Errorf("%w, create it with init", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/awnumar/memguard"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func runBackup(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("backup")
+	if err := c.parseCommandFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	wallet, password, err := c.openWallet(sdk)
+	if err != nil {
+		return err
+	}
+
+	// The backup is encrypted with the current Stronghold password
+	if err := wallet.Backup(flags.Arg(0), password); err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{{"backup", flags.Arg(0)}})
+}
+
+func runRestore(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("restore")
+	ignoreCoinType := flags.Bool("ignore-coin-type", false, "if the backup has another coin type, keep the accounts and coin type of the wallet and only restore the secrets")
+	if err := c.parseCommandFlags(flags, args, 1); err != nil {
+		return err
+	}
+
+	if c.ledger {
+		return errors.New("backups can only be restored into Stronghold wallets")
+	}
+
+	// The wallet is opened with the password of the backup, which becomes the password of the restored snapshot
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	wallet, password, err := c.openWallet(sdk)
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.RestoreBackup(flags.Arg(0), password, *ignoreCoinType); err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{{"restored", flags.Arg(0)}})
+}
+
+func runChangePassword(_ context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("change-password")
+	if err := c.parseCommandFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	if c.ledger {
+		return errors.New("a Ledger has no password")
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	wallet, password, err := c.openWallet(sdk)
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := c.prompt.newSecret("New Stronghold password")
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.ChangeStrongholdPassword(password, newPassword); err != nil {
+		return err
+	}
+
+	return c.printFields([][2]string{{"stronghold", c.strongholdPath}})
+}
+
+func runBalance(ctx context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("balance")
+	accountIndex := flags.Uint("account", 0, "account index")
+	if err := c.parseCommandFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	wallet, _, err := c.openWallet(sdk)
+	if err != nil {
+		return err
+	}
+
+	account, err := c.account(ctx, wallet, uint32(*accountIndex))
+	if err != nil {
+		return err
+	}
+
+	balance, err := account.Sync(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.print(balance, func(w io.Writer) {
+		fmt.Fprintf(w, "available:\t%s\n", formatAmount(network, balance.BaseCoin.Available))
+		fmt.Fprintf(w, "total:\t%s\n", formatAmount(network, balance.BaseCoin.Total))
+		for _, token := range balance.NativeTokens {
+			fmt.Fprintf(w, "%s:\t%s\n", token.TokenId, token.Available)
+		}
+		fmt.Fprintf(w, "nfts:\t%d\n", len(balance.Nfts))
+	})
+}
+
+func runSend(ctx context.Context, c *cli, args []string) error {
+	flags := c.commandFlags("send")
+	accountIndex := flags.Uint("account", 0, "account index")
+	wait := flags.Bool("wait", false, "wait until the transaction is included")
+	if err := c.parseCommandFlags(flags, args, 2); err != nil {
+		return err
+	}
+
+	address, amount := flags.Arg(0), flags.Arg(1)
+	if value, ok := new(big.Int).SetString(amount, 10); !ok || value.Sign() <= 0 {
+		return fmt.Errorf("invalid amount %q, expected a positive number of base units", amount)
+	}
+
+	sdk, err := c.loadSDK()
+	if err != nil {
+		return err
+	}
+	defer sdk.Destroy()
+
+	wallet, _, err := c.openWallet(sdk)
+	if err != nil {
+		return err
+	}
+
+	account, err := c.account(ctx, wallet, uint32(*accountIndex))
+	if err != nil {
+		return err
+	}
+
+	if _, err := account.Sync(ctx, nil); err != nil {
+		return err
+	}
+
+	transaction, err := account.Send([]types.SendParams{{Address: address, Amount: amount}}, nil)
+	if err != nil {
+		return err
+	}
+
+	fields := [][2]string{{"transactionId", string(transaction.TransactionId)}}
+	if *wait {
+		blockId, err := account.RetryTransactionUntilIncluded(ctx, transaction.TransactionId, 0, 0)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, [2]string{"blockId", string(blockId)})
+	}
+
+	return c.printFields(fields)
+}
+
+// openWallet opens the wallet database with the Ledger or Stronghold secret manager, it's closed with the SDK.
+// For Stronghold the prompted password is returned, as some operations require it again.
+func (c *cli) openWallet(sdk *wasp_wallet_sdk.IOTASDK) (*wasp_wallet_sdk.Wallet, *memguard.Enclave, error) {
+	clientOptions := c.clientOptions
+	if c.node != "" {
+		clientOptions = &types.ClientOptions{Nodes: []any{c.node}}
+	}
+	if clientOptions == nil {
+		return nil, nil, errors.New("-node or a profile with nodes is required by wallet commands")
+	}
+
+	network, err := c.network()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options := types.WalletOptions{
+		ClientOptions: clientOptions,
+		CoinType:      network.CoinType,
+		StoragePath:   c.storagePath,
+	}
+
+	if c.ledger {
+		options.SecretManager = &types.LedgerNanoSecretManager{LedgerNano: c.emulator}
+
+		wallet, err := sdk.CreateWallet(options)
+
+		return wallet, nil, err
+	}
+
+	if err := c.requireSnapshot(); err != nil {
+		return nil, nil, err
+	}
+
+	password, err := c.strongholdPassword()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buffer, err := password.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer buffer.Destroy()
+
+	options.SecretManager = types.StrongholdSecretManager{
+		Stronghold: types.StrongholdSecretManagerOptions{
+			Password:     buffer.String(),
+			SnapshotPath: c.strongholdPath,
+		},
+	}
+
+	wallet, err := sdk.CreateWallet(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.checkStronghold(wallet); err != nil {
+		wallet.Destroy()
+		return nil, nil, err
+	}
+
+	return wallet, password, nil
+}
+
+// account returns the account with the index, the first account is created if the wallet has none
+func (c *cli) account(ctx context.Context, wallet *wasp_wallet_sdk.Wallet, accountIndex uint32) (*wasp_wallet_sdk.Account, error) {
+	indexes, err := wallet.GetAccountIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if index == accountIndex {
+			return wallet.Account(accountIndex), nil
+		}
+	}
+
+	if len(indexes) > 0 || accountIndex != 0 {
+		return nil, fmt.Errorf("account %d does not exist", accountIndex)
+	}
+
+	hrp, err := c.bech32Hrp()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := wallet.CreateAccount(ctx, types.CreateAccountOptions{Bech32Hrp: hrp}); err != nil {
+		return nil, err
+	}
+
+	return wallet.Account(accountIndex), nil
+}
+
+// formatAmount formats a decimal amount of base units in the token of the network
+func formatAmount(network types.Network, amount string) string {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return amount
+	}
+
+	return network.FormatAmount(value)
+}
@@ -0,0 +1,357 @@
+// Package config loads wallet options from YAML or TOML files, with environment variable overrides.
+// Secrets are never part of a config file, it only refers to their sources (see ResolveSecret).
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+type SecretManagerType string
+
+const (
+	SecretManagerStronghold SecretManagerType = "stronghold"
+	SecretManagerLedger     SecretManagerType = "ledger"
+	SecretManagerMnemonic   SecretManagerType = "mnemonic"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid config")
+	ErrInlineSecret  = errors.New("secrets must not be inlined, refer to their source instead")
+)
+
+// inlineSecretKeys are rejected in the secret manager section, to keep secrets out of config files
+var inlineSecretKeys = map[string]string{
+	"password": "passwordSource",
+	"mnemonic": "mnemonicSource",
+}
+
+// Config is the content of a wallet config file
+type Config struct {
+	// Network name, e.g. "shimmer", used to default the coin type
+	Network string `yaml:"network,omitempty" toml:"network,omitempty"`
+
+	// CoinType of the wallet, takes precedence over the coin type of the network
+	CoinType types.CoinType `yaml:"coinType,omitempty" toml:"coinType,omitempty"`
+
+	// StoragePath of the wallet database
+	StoragePath string `yaml:"storagePath,omitempty" toml:"storagePath,omitempty"`
+
+	ClientOptions *types.ClientOptions `yaml:"clientOptions,omitempty" toml:"clientOptions,omitempty"`
+	SecretManager SecretManagerConfig  `yaml:"secretManager" toml:"secretManager"`
+	Logger        *types.ILoggerConfig `yaml:"logger,omitempty" toml:"logger,omitempty"`
+}
+
+// SecretManagerConfig selects the secret manager by its type, only the fields of the type may be set
+type SecretManagerConfig struct {
+	Type SecretManagerType `yaml:"type" toml:"type"`
+
+	// SnapshotPath of the Stronghold snapshot
+	SnapshotPath string `yaml:"snapshotPath,omitempty" toml:"snapshotPath,omitempty"`
+
+	// PasswordSource of the Stronghold password, see ResolveSecret
+	PasswordSource string `yaml:"passwordSource,omitempty" toml:"passwordSource,omitempty"`
+
+	// Emulator selects the Speculos simulator instead of a Ledger device
+	Emulator bool `yaml:"emulator,omitempty" toml:"emulator,omitempty"`
+
+	// MnemonicSource of the mnemonic secret manager, see ResolveSecret
+	MnemonicSource string `yaml:"mnemonicSource,omitempty" toml:"mnemonicSource,omitempty"`
+}
+
+// ValidationError describes an invalid field, by its path in the config file
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Load reads the config file, applies environment overrides and returns the validated wallet options with secrets resolved
+func Load(path string) (types.WalletOptions, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return types.WalletOptions{}, err
+	}
+
+	return config.WalletOptions()
+}
+
+// LoadConfig reads and validates the config file without resolving secrets, the format is detected by the file extension
+func LoadConfig(path string) (*Config, error) {
+	format, err := formatOf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Parse decodes and validates a config, unknown fields are rejected. Environment overrides are applied before validation.
+func Parse(data []byte, format Format) (*Config, error) {
+	var raw map[string]any
+	if err := decode(data, format, &raw, false); err != nil {
+		return nil, err
+	}
+
+	if err := checkInlineSecrets(raw); err != nil {
+		return nil, err
+	}
+
+	config := new(Config)
+	if err := decode(data, format, config, true); err != nil {
+		return nil, err
+	}
+
+	if err := config.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Validate returns all validation errors joined
+func (c *Config) Validate() error {
+	return joinValidationErrors(c.validationErrors())
+}
+
+const (
+	requiredNodesMessage          = "at least one node is required"
+	requiredPasswordSourceMessage = "required for stronghold"
+)
+
+func (c *Config) validationErrors() []*ValidationError {
+	var errs []*ValidationError
+	invalid := func(field string, format string, args ...any) {
+		errs = append(errs, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if coinType, err := c.coinType(); err != nil {
+		invalid("network", "%v", err)
+	} else if coinType == 0 {
+		invalid("coinType", "required, set either coinType or network")
+	}
+
+	if c.StoragePath == "" {
+		invalid("storagePath", "required")
+	}
+
+	if c.ClientOptions == nil || len(c.ClientOptions.Nodes) == 0 {
+		invalid("clientOptions.nodes", requiredNodesMessage)
+	}
+
+	secretManager := c.SecretManager
+	unexpected := func(set bool, field string) {
+		if set {
+			invalid("secretManager."+field, "not used by secret manager type %q", secretManager.Type)
+		}
+	}
+
+	switch secretManager.Type {
+	case SecretManagerStronghold:
+		if secretManager.SnapshotPath == "" {
+			invalid("secretManager.snapshotPath", "required for stronghold")
+		}
+		if secretManager.PasswordSource == "" {
+			invalid("secretManager.passwordSource", requiredPasswordSourceMessage)
+		}
+		unexpected(secretManager.Emulator, "emulator")
+		unexpected(secretManager.MnemonicSource != "", "mnemonicSource")
+	case SecretManagerLedger:
+		unexpected(secretManager.SnapshotPath != "", "snapshotPath")
+		unexpected(secretManager.PasswordSource != "", "passwordSource")
+		unexpected(secretManager.MnemonicSource != "", "mnemonicSource")
+	case SecretManagerMnemonic:
+		if secretManager.MnemonicSource == "" {
+			invalid("secretManager.mnemonicSource", "required for mnemonic")
+		}
+		unexpected(secretManager.SnapshotPath != "", "snapshotPath")
+		unexpected(secretManager.PasswordSource != "", "passwordSource")
+		unexpected(secretManager.Emulator, "emulator")
+	case "":
+		invalid("secretManager.type", "required, one of stronghold, ledger or mnemonic")
+	default:
+		invalid("secretManager.type", "unknown type %q, expected stronghold, ledger or mnemonic", secretManager.Type)
+	}
+
+	for field, source := range map[string]string{
+		"secretManager.passwordSource": secretManager.PasswordSource,
+		"secretManager.mnemonicSource": secretManager.MnemonicSource,
+	} {
+		if source == "" {
+			continue
+		}
+		if _, _, err := parseSecretSource(source); err != nil {
+			invalid(field, "%v", err)
+		}
+	}
+
+	return errs
+}
+
+// joinValidationErrors sorts the errors by field, so the error message is stable
+func joinValidationErrors(errs []*ValidationError) error {
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+
+	return errors.Join(joined...)
+}
+
+// WalletOptions resolves the secret sources and returns the options to create the wallet with
+func (c *Config) WalletOptions() (types.WalletOptions, error) {
+	coinType, err := c.coinType()
+	if err != nil {
+		return types.WalletOptions{}, err
+	}
+
+	options := types.WalletOptions{
+		ClientOptions: c.ClientOptions,
+		CoinType:      coinType,
+		StoragePath:   c.StoragePath,
+	}
+
+	switch c.SecretManager.Type {
+	case SecretManagerStronghold:
+		password, err := resolveSecretString(c.SecretManager.PasswordSource)
+		if err != nil {
+			return types.WalletOptions{}, fmt.Errorf("secretManager.passwordSource: %w", err)
+		}
+
+		options.SecretManager = types.StrongholdSecretManager{
+			Stronghold: types.StrongholdSecretManagerOptions{
+				Password:     password,
+				SnapshotPath: c.SecretManager.SnapshotPath,
+			},
+		}
+	case SecretManagerLedger:
+		options.SecretManager = &types.LedgerNanoSecretManager{
+			LedgerNano: c.SecretManager.Emulator,
+		}
+	case SecretManagerMnemonic:
+		mnemonic, err := resolveSecretString(c.SecretManager.MnemonicSource)
+		if err != nil {
+			return types.WalletOptions{}, fmt.Errorf("secretManager.mnemonicSource: %w", err)
+		}
+
+		options.SecretManager = types.MnemonicSecretManager{
+			Mnemonic: mnemonic,
+		}
+	default:
+		return types.WalletOptions{}, &ValidationError{Field: "secretManager.type", Message: fmt.Sprintf("unknown type %q", c.SecretManager.Type)}
+	}
+
+	return options, nil
+}
+
+func (c *Config) coinType() (types.CoinType, error) {
+	if c.Network == "" {
+		return c.CoinType, nil
+	}
+
+	network, err := types.NetworkByName(c.Network)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.CoinType != 0 && c.CoinType != network.CoinType {
+		return 0, fmt.Errorf("coin type %d doesn't match network %s", c.CoinType, network.Name)
+	}
+
+	return network.CoinType, nil
+}
+
+func formatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported config file %s, expected .yaml, .yml or .toml", path)
+	}
+}
+
+func decode(data []byte, format Format, target any, strict bool) error {
+	switch format {
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(strict)
+
+		if err := decoder.Decode(target); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+
+		return nil
+	case FormatTOML:
+		metadata, err := toml.Decode(string(data), target)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+
+		if undecoded := metadata.Undecoded(); strict && len(undecoded) > 0 {
+			return fmt.Errorf("%w: unknown fields %v", ErrInvalidConfig, undecoded)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown config format %q", format)
+	}
+}
+
+func checkInlineSecrets(raw map[string]any) error {
+	secretManager, ok := raw["secretManager"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	for key := range secretManager {
+		if source, ok := inlineSecretKeys[strings.ToLower(key)]; ok {
+			return fmt.Errorf("%w: secretManager.%s, use secretManager.%s", ErrInlineSecret, key, source)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// EnvPrefix prefixes the environment variables overriding config fields
+const EnvPrefix = "WASP_WALLET_"
+
+// envOverrides maps the environment variables to the config fields they override, in the order they are applied.
+// Overriding the secret manager type with another one clears the fields of the previous type, the fields of the new type follow it.
+var envOverrides = []struct {
+	name  string
+	apply func(c *Config, value string) error
+}{
+	{"NETWORK", func(c *Config, value string) error {
+		c.Network = value
+		return nil
+	}},
+	{"COIN_TYPE", func(c *Config, value string) error {
+		coinType, err := strconv.ParseUint(value, 10, 32)
+		c.CoinType = types.CoinType(coinType)
+		return err
+	}},
+	{"STORAGE_PATH", func(c *Config, value string) error {
+		c.StoragePath = value
+		return nil
+	}},
+	{"NODES", func(c *Config, value string) error {
+		if c.ClientOptions == nil {
+			c.ClientOptions = new(types.ClientOptions)
+		}
+		c.ClientOptions.Nodes = nil
+		for _, node := range strings.Split(value, ",") {
+			if node = strings.TrimSpace(node); node != "" {
+				c.ClientOptions.Nodes = append(c.ClientOptions.Nodes, node)
+			}
+		}
+		return nil
+	}},
+	{"SECRET_MANAGER_TYPE", func(c *Config, value string) error {
+		if SecretManagerType(value) != c.SecretManager.Type {
+			c.SecretManager = SecretManagerConfig{Type: SecretManagerType(value)}
+		}
+		return nil
+	}},
+	{"SNAPSHOT_PATH", func(c *Config, value string) error {
+		c.SecretManager.SnapshotPath = value
+		return nil
+	}},
+	{"PASSWORD_SOURCE", func(c *Config, value string) error {
+		c.SecretManager.PasswordSource = value
+		return nil
+	}},
+	{"MNEMONIC_SOURCE", func(c *Config, value string) error {
+		c.SecretManager.MnemonicSource = value
+		return nil
+	}},
+	{"LEDGER_EMULATOR", func(c *Config, value string) error {
+		emulator, err := strconv.ParseBool(value)
+		c.SecretManager.Emulator = emulator
+		return err
+	}},
+}
+
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) error {
+	for _, override := range envOverrides {
+		value, ok := lookupEnv(EnvPrefix + override.name)
+		if !ok {
+			continue
+		}
+
+		if err := override.apply(c, value); err != nil {
+			return fmt.Errorf("%w: %s%s: %v", ErrInvalidConfig, EnvPrefix, override.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,446 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const (
+	profileExtension = ".yaml"
+
+	// currentProfileFile holds the name of the profile selected by Switch
+	currentProfileFile = "current"
+)
+
+var (
+	ErrProfileNotFound    = errors.New("profile not found")
+	ErrProfileExists      = errors.New("profile already exists")
+	ErrNoCurrentProfile   = errors.New("no current profile")
+	ErrCoinTypeMismatch   = errors.New("stronghold snapshot is used with another coin type")
+	ErrAddressMismatch    = errors.New("stronghold snapshot doesn't match the profile")
+	ErrInvalidProfileName = errors.New("invalid profile name")
+)
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Profile is a named wallet setup, e.g. for Shimmer mainnet, testnet or a private Wasp devnet
+type Profile struct {
+	// Name of the profile, it's the name of the profile file
+	Name string `yaml:"-"`
+
+	// Network name, see types.NetworkByName
+	Network string `yaml:"network"`
+
+	// CoinType of a private network, which is registered with types.RegisterNetwork when the profile is loaded.
+	// It's optional for known networks, and has to match their coin type.
+	CoinType types.CoinType `yaml:"coinType,omitempty"`
+
+	// Bech32Hrp overrides the HRP of the network, e.g. with its testnet HRP. Private networks use it as their HRP.
+	Bech32Hrp string `yaml:"bech32Hrp,omitempty"`
+
+	// Address is the first address of the Stronghold snapshot, it's recorded when the snapshot is first opened through the profile.
+	// A snapshot with another first address is another snapshot, or it was opened with another coin type, see CheckStronghold.
+	Address string `yaml:"address,omitempty"`
+
+	StoragePath   string               `yaml:"storagePath"`
+	SecretManager SecretManagerConfig  `yaml:"secretManager"`
+	ClientOptions *types.ClientOptions `yaml:"clientOptions"`
+}
+
+// Config returns the profile as wallet config, to validate it and resolve the wallet options
+func (p *Profile) Config() *Config {
+	return &Config{
+		Network:       p.Network,
+		CoinType:      p.CoinType,
+		StoragePath:   p.StoragePath,
+		ClientOptions: p.ClientOptions,
+		SecretManager: p.SecretManager,
+	}
+}
+
+// Validate checks the profile. Nodes and the Stronghold password source are optional,
+// e.g. the CLI takes them from flags or prompts for the password, OpenProfile requires them.
+func (p *Profile) Validate() error {
+	if !profileNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("%w: %q, only letters, digits, - and _ are allowed", ErrInvalidProfileName, p.Name)
+	}
+
+	var errs []*ValidationError
+	if p.Network == "" {
+		errs = append(errs, &ValidationError{Field: "network", Message: "required"})
+	}
+	for _, err := range p.Config().validationErrors() {
+		// The coin type of a profile defaults to the one of its network
+		if err.Field == "coinType" || isOptionalInProfile(err) {
+			continue
+		}
+		errs = append(errs, err)
+	}
+
+	// Only a valid network can lack the HRP, invalid ones are reported above
+	if _, err := p.ResolvedCoinType(); err == nil && p.Network != "" {
+		if _, err := p.ResolvedBech32Hrp(); err != nil {
+			errs = append(errs, &ValidationError{Field: "bech32Hrp", Message: err.Error()})
+		}
+	}
+
+	if err := joinValidationErrors(errs); err != nil {
+		return fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+// isOptionalInProfile reports whether the error is about a field a profile may leave out
+func isOptionalInProfile(err *ValidationError) bool {
+	return (err.Field == "clientOptions.nodes" && err.Message == requiredNodesMessage) ||
+		(err.Field == "secretManager.passwordSource" && err.Message == requiredPasswordSourceMessage)
+}
+
+// ResolvedCoinType returns the coin type of the network
+func (p *Profile) ResolvedCoinType() (types.CoinType, error) {
+	return p.Config().coinType()
+}
+
+// ResolvedBech32Hrp returns the HRP override, or the HRP of the network
+func (p *Profile) ResolvedBech32Hrp() (string, error) {
+	coinType, err := p.ResolvedCoinType()
+	if err != nil {
+		return "", err
+	}
+
+	return types.ResolveBech32Hrp(coinType, p.Bech32Hrp)
+}
+
+// Profiles is a directory of profile files, with one of them selected as current
+type Profiles struct {
+	dir string
+}
+
+// DefaultProfilesDir is the profiles directory in the user config directory
+func DefaultProfilesDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "wasp-wallet", "profiles"), nil
+}
+
+// NewProfiles opens the profiles directory, it's created when the first profile is added
+func NewProfiles(dir string) *Profiles {
+	return &Profiles{dir: dir}
+}
+
+func (p *Profiles) Dir() string {
+	return p.dir
+}
+
+// List returns the profiles sorted by name
+func (p *Profiles) List() ([]Profile, error) {
+	entries, err := os.ReadDir(p.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []Profile
+	for _, entry := range entries {
+		name, isProfile := strings.CutSuffix(entry.Name(), profileExtension)
+		if !isProfile || entry.IsDir() {
+			continue
+		}
+
+		profile, err := p.Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, *profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+func (p *Profiles) Get(name string) (*Profile, error) {
+	if !profileNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProfileName, name)
+	}
+
+	data, err := os.ReadFile(p.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := decode(data, FormatYAML, &raw, false); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	if err := checkInlineSecrets(raw); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	profile := &Profile{Name: name}
+	if err := decode(data, FormatYAML, profile, true); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	if err := profile.registerNetwork(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// registerNetwork registers the private network of the profile, known networks are left as they are
+func (p *Profile) registerNetwork() error {
+	if p.CoinType == 0 || p.Network == "" {
+		return nil
+	}
+
+	if _, err := types.NetworkByName(p.Network); err == nil {
+		return nil
+	}
+
+	// Private networks only have the HRP and coin type of the profile, their token is named after the network
+	if err := types.RegisterNetwork(types.Network{
+		Name:        p.Network,
+		CoinType:    p.CoinType,
+		Bech32Hrp:   p.Bech32Hrp,
+		Decimals:    types.NetworkShimmer.Decimals,
+		TokenSymbol: strings.ToUpper(p.Network),
+	}); err != nil {
+		return fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+// Add validates and stores a new profile.
+// It's rejected if another profile uses the same Stronghold snapshot with a different coin type.
+func (p *Profiles) Add(profile Profile) error {
+	if err := profile.registerNetwork(); err != nil {
+		return err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(p.path(profile.Name)); err == nil {
+		return fmt.Errorf("%w: %s", ErrProfileExists, profile.Name)
+	}
+
+	if err := p.checkSnapshotCoinType(profile); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(p.dir, 0o700); err != nil {
+		return err
+	}
+
+	return p.write(&profile)
+}
+
+func (p *Profiles) write(profile *Profile) error {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(profile); err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.path(profile.Name), buffer.Bytes(), 0o600)
+}
+
+// Remove deletes the profile, but not its wallet database or Stronghold snapshot
+func (p *Profiles) Remove(name string) error {
+	if _, err := p.Get(name); err != nil {
+		return err
+	}
+
+	if current, err := p.currentName(); err == nil && current == name {
+		if err := os.Remove(filepath.Join(p.dir, currentProfileFile)); err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(p.path(name))
+}
+
+// Switch selects the current profile
+func (p *Profiles) Switch(name string) error {
+	if _, err := p.Get(name); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(p.dir, currentProfileFile), []byte(name+"\n"), 0o600)
+}
+
+func (p *Profiles) Current() (*Profile, error) {
+	name, err := p.currentName()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Get(name)
+}
+
+// OpenProfile creates the wallet of the profile, an empty name opens the current profile.
+// The profile needs nodes and, for Stronghold, a password source. New accounts of the wallet use the HRP of the profile.
+// Stronghold snapshots are checked against the first address recorded in the profile, see CheckStronghold.
+func (p *Profiles) OpenProfile(sdk *wasp_wallet_sdk.IOTASDK, name string) (*wasp_wallet_sdk.Wallet, error) {
+	var profile *Profile
+	var err error
+	if name == "" {
+		profile, err = p.Current()
+	} else {
+		profile, err = p.Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Opening the wallet requires the fields that are optional in profiles
+	if err := profile.Config().Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
+
+	coinType, err := profile.ResolvedCoinType()
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := profile.Config().WalletOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := sdk.CreateWallet(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address is only recorded once the snapshot was opened, a wrong password or an unreachable node must not record it
+	if profile.SecretManager.Type == SecretManagerStronghold {
+		if err := p.CheckStronghold(profile, wallet, coinType); err != nil {
+			wallet.Destroy()
+			return nil, err
+		}
+	}
+
+	wallet.SetBech32Hrp(profile.Bech32Hrp)
+
+	return wallet, nil
+}
+
+// AddressGenerator derives the addresses of a secret manager, e.g. a wasp_wallet_sdk.Wallet or wasp_wallet_sdk.SecretManager
+type AddressGenerator interface {
+	GenerateEd25519Address(addressIndex uint32, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) (string, error)
+}
+
+// CheckStronghold derives the first address of the opened Stronghold snapshot of the profile with the coin type it's used with.
+// The address is recorded in the profile on first use, afterwards another address fails with ErrAddressMismatch:
+// the snapshot was replaced, or it's opened with another coin type than before. Moving the snapshot doesn't matter.
+func (p *Profiles) CheckStronghold(profile *Profile, secretManager AddressGenerator, coinType types.CoinType) error {
+	hrp, err := profile.ResolvedBech32Hrp()
+	if err != nil {
+		return err
+	}
+
+	address, err := secretManager.GenerateEd25519Address(0, 0, hrp, coinType, nil)
+	if err != nil {
+		return err
+	}
+
+	if profile.Address == "" {
+		profile.Address = address
+		return p.write(profile)
+	}
+
+	if address != profile.Address {
+		return fmt.Errorf("%w: the first address of %s with coin type %d is %s, the profile %s recorded %s",
+			ErrAddressMismatch, profile.SecretManager.SnapshotPath, coinType, address, profile.Name, profile.Address)
+	}
+
+	return nil
+}
+
+func (p *Profiles) path(name string) string {
+	return filepath.Join(p.dir, name+profileExtension)
+}
+
+func (p *Profiles) currentName() (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, currentProfileFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoCurrentProfile
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (p *Profiles) checkSnapshotCoinType(profile Profile) error {
+	if profile.SecretManager.Type != SecretManagerStronghold {
+		return nil
+	}
+
+	coinType, err := profile.ResolvedCoinType()
+	if err != nil {
+		return err
+	}
+
+	snapshotPath, err := filepath.Abs(profile.SecretManager.SnapshotPath)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := p.List()
+	if err != nil {
+		return err
+	}
+
+	for _, other := range profiles {
+		if other.SecretManager.Type != SecretManagerStronghold {
+			continue
+		}
+
+		otherSnapshotPath, err := filepath.Abs(other.SecretManager.SnapshotPath)
+		if err != nil || otherSnapshotPath != snapshotPath {
+			continue
+		}
+
+		if otherCoinType, err := other.ResolvedCoinType(); err == nil && otherCoinType != coinType {
+			return fmt.Errorf("%w: profile %s uses %s with coin type %d", ErrCoinTypeMismatch, other.Name, other.SecretManager.SnapshotPath, otherCoinType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/awnumar/memguard"
+)
+
+const (
+	secretSourceEnv  = "env"
+	secretSourceFile = "file"
+)
+
+var ErrSecretUnavailable = errors.New("secret unavailable")
+
+// ResolveSecret reads a secret from its source:
+//
+//	env:NAME    the environment variable NAME
+//	file:PATH   the content of the file, without trailing newline (e.g. a Docker or systemd credential)
+//
+// ${NAME} in the source is replaced by the environment variable, e.g. "file:${CREDENTIALS_DIRECTORY}/password".
+func ResolveSecret(source string) (*memguard.Enclave, error) {
+	secret, err := readSecret(source)
+	if err != nil {
+		return nil, err
+	}
+
+	// NewEnclave wipes the plain secret
+	return memguard.NewEnclave(secret), nil
+}
+
+// resolveSecretString is ResolveSecret for the SDK options, which take secrets as strings
+func resolveSecretString(source string) (string, error) {
+	secret, err := readSecret(source)
+	if err != nil {
+		return "", err
+	}
+	defer memguard.WipeBytes(secret)
+
+	return string(secret), nil
+}
+
+func readSecret(source string) ([]byte, error) {
+	kind, location, err := parseSecretSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret []byte
+	switch kind {
+	case secretSourceEnv:
+		value, ok := os.LookupEnv(location)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: environment variable %s is not set", ErrSecretUnavailable, location)
+		}
+		secret = []byte(value)
+	case secretSourceFile:
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSecretUnavailable, err)
+		}
+		secret = bytes.TrimRight(data, "\r\n")
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("%w: %s is empty", ErrSecretUnavailable, location)
+		}
+	}
+
+	return secret, nil
+}
+
+// parseSecretSource expands environment variables and splits the source into kind and location
+func parseSecretSource(source string) (string, string, error) {
+	var missing []string
+	expanded := os.Expand(source, func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("undefined environment variables %s in secret source", strings.Join(missing, ", "))
+	}
+
+	kind, location, ok := strings.Cut(expanded, ":")
+	if !ok || location == "" {
+		return "", "", fmt.Errorf("invalid secret source %q, expected env:NAME or file:PATH", source)
+	}
+
+	switch kind {
+	case secretSourceEnv, secretSourceFile:
+		return kind, location, nil
+	default:
+		return "", "", fmt.Errorf("unknown secret source %q, expected env or file", kind)
+	}
+}
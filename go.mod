@@ -1,23 +1,29 @@
 module github.com/iotaledger/wasp-wallet-sdk
 
-go 1.20
+go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/awnumar/memguard v0.22.4
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1
 	github.com/ebitengine/purego v0.6.1
 	github.com/goccy/go-json v0.10.2
 	github.com/stretchr/testify v1.9.0
+	github.com/zalando/go-keyring v0.2.5
+	golang.org/x/crypto v0.21.0
 	golang.org/x/sys v0.18.0
+	golang.org/x/term v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/avelex/procmem v0.0.0-20230620042645-95229f08a1c9 // indirect
+	github.com/alessio/shellescape v1.4.1 // indirect
 	github.com/awnumar/memcall v0.2.0 // indirect
+	github.com/danieljoos/wincred v1.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
 	github.com/kr/pretty v0.3.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
-	golang.org/x/crypto v0.21.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,125 @@
+package hdwallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/awnumar/memguard"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Hardened is added to an index for hardened derivation
+const Hardened uint32 = 0x80000000
+
+var ErrNonHardenedIndex = errors.New("SLIP-10 Ed25519 derivation only supports hardened indexes")
+
+// SeedFromMnemonic returns the BIP39 seed of a mnemonic. The caller should wipe the seed after use.
+// The mnemonic isn't checked against the word list, use Utils.VerifyMnemonic for that.
+func SeedFromMnemonic(mnemonic []byte, passphrase []byte) []byte {
+	normalized := bytes.Join(bytes.Fields(mnemonic), []byte(" "))
+	defer memguard.WipeBytes(normalized)
+
+	salt := append([]byte("mnemonic"), passphrase...)
+	defer memguard.WipeBytes(salt)
+
+	return pbkdf2.Key(normalized, salt, 2048, 64, sha512.New)
+}
+
+// DeriveEd25519 returns the Ed25519 private key seed of the path as specified by SLIP-10
+func DeriveEd25519(seed []byte, path []uint32) ([]byte, error) {
+	key, chainCode := splitHMAC([]byte("ed25519 seed"), seed)
+	defer memguard.WipeBytes(chainCode)
+
+	for _, index := range path {
+		if index < Hardened {
+			memguard.WipeBytes(key)
+			return nil, fmt.Errorf("%w: %d", ErrNonHardenedIndex, index)
+		}
+
+		childKey, childChainCode := splitHMAC(chainCode, hardenedData(key, index))
+		memguard.WipeBytes(key)
+		memguard.WipeBytes(chainCode)
+		key, chainCode = childKey, childChainCode
+	}
+
+	return key, nil
+}
+
+// DeriveSecp256k1 returns the secp256k1 private key of the path as specified by BIP32
+func DeriveSecp256k1(seed []byte, path []uint32) ([]byte, error) {
+	privateKey, err := deriveSecp256k1(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	defer privateKey.Zero()
+
+	keyBytes := privateKey.Key.Bytes()
+	defer memguard.WipeBytes(keyBytes[:])
+
+	return append([]byte(nil), keyBytes[:]...), nil
+}
+
+// deriveSecp256k1 is DeriveSecp256k1 without serializing the key. The caller should zero the key after use.
+func deriveSecp256k1(seed []byte, path []uint32) (*secp256k1.PrivateKey, error) {
+	key, chainCode := splitHMAC([]byte("Bitcoin seed"), seed)
+	defer memguard.WipeBytes(chainCode)
+
+	privateKey, err := secp256k1PrivateKey(key)
+	memguard.WipeBytes(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= Hardened {
+			keyBytes := privateKey.Key.Bytes()
+			data = hardenedData(keyBytes[:], index)
+			memguard.WipeBytes(keyBytes[:])
+		} else {
+			data = binary.BigEndian.AppendUint32(privateKey.PubKey().SerializeCompressed(), index)
+		}
+
+		tweak, childChainCode := splitHMAC(chainCode, data)
+		memguard.WipeBytes(data)
+		memguard.WipeBytes(chainCode)
+		chainCode = childChainCode
+
+		// The child key is (tweak + key) mod n, both have to be valid keys
+		var tweakScalar secp256k1.ModNScalar
+		overflow := tweakScalar.SetByteSlice(tweak)
+		memguard.WipeBytes(tweak)
+		privateKey.Key.Add(&tweakScalar)
+		tweakScalar.Zero()
+
+		if overflow || privateKey.Key.IsZero() {
+			privateKey.Zero()
+			return nil, fmt.Errorf("%w: index %d", errInvalidSecp256k1Key, index)
+		}
+	}
+
+	return privateKey, nil
+}
+
+// splitHMAC returns both halves of HMAC-SHA512, the key and the chain code
+func splitHMAC(key []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
+
+// hardenedData is the HMAC input of a hardened child, 0x00 || key || index
+func hardenedData(key []byte, index uint32) []byte {
+	data := make([]byte, 0, 37)
+	data = append(data, 0x00)
+	data = append(data, key...)
+
+	return binary.BigEndian.AppendUint32(data, index)
+}
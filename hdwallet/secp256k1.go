@@ -0,0 +1,30 @@
+package hdwallet
+
+import (
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+var errInvalidSecp256k1Key = errors.New("invalid secp256k1 key")
+
+// secp256k1PrivateKey parses a private key, it has to be in [1, n-1]. The caller should zero the key after use.
+func secp256k1PrivateKey(privateKey []byte) (*secp256k1.PrivateKey, error) {
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(privateKey); overflow || scalar.IsZero() {
+		scalar.Zero()
+		return nil, errInvalidSecp256k1Key
+	}
+
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+// signSecp256k1 returns the recoverable signature r || s || v of the hash, with a nonce as specified by RFC 6979
+// and a low s value. This matches the signatures of the native secret managers.
+func signSecp256k1(privateKey *secp256k1.PrivateKey, hash []byte) []byte {
+	// The compact signature is the recovery code + 27 followed by r || s
+	compact := ecdsa.SignCompact(privateKey, hash, false)
+
+	return append(compact[1:], compact[0]-27)
+}
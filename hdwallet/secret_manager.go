@@ -0,0 +1,264 @@
+// Package hdwallet derives and signs with the keys of a mnemonic in pure Go, without the native library.
+package hdwallet
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sync"
+
+	"github.com/awnumar/memguard"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+var (
+	ErrSecretManagerClosed = errors.New("secret manager closed")
+
+	// ErrUnsupported is returned by the methods of Ledger and Stronghold secret managers
+	ErrUnsupported = errors.New("not supported by a mnemonic secret manager")
+)
+
+var _ wasp_wallet_sdk.Signer = (*SecretManager)(nil)
+
+// SecretManager is an in-memory mnemonic secret manager with the methods of the native one.
+// Ed25519 keys are derived with SLIP-10, secp256k1 keys with BIP32, and the signatures match the native ones.
+type SecretManager struct {
+	mutex sync.RWMutex
+	seed  *memguard.Enclave
+}
+
+// NewMnemonicSecretManager derives the seed of the mnemonic, the mnemonic isn't kept
+func NewMnemonicSecretManager(mnemonic *memguard.Enclave) (*SecretManager, error) {
+	buffer, err := mnemonic.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer buffer.Destroy()
+
+	return NewSeedSecretManager(memguard.NewEnclave(SeedFromMnemonic(buffer.Bytes(), nil)))
+}
+
+// NewSeedSecretManager uses a BIP39 seed
+func NewSeedSecretManager(seed *memguard.Enclave) (*SecretManager, error) {
+	if seed == nil {
+		return nil, errors.New("seed required")
+	}
+
+	return &SecretManager{seed: seed}, nil
+}
+
+// Close drops the seed, further calls fail with ErrSecretManagerClosed
+func (s *SecretManager) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.seed = nil
+
+	return nil
+}
+
+// Destroy is Close without reporting errors
+func (s *SecretManager) Destroy() {
+	_ = s.Close()
+}
+
+// GetLedgerStatus fails with ErrUnsupported, as the native mnemonic secret manager does
+func (s *SecretManager) GetLedgerStatus() (*types.LedgerNanoStatus, error) {
+	return nil, ErrUnsupported
+}
+
+// StoreMnemonic fails with ErrUnsupported, the mnemonic can't be replaced
+func (s *SecretManager) StoreMnemonic(*memguard.Enclave) (bool, error) {
+	return false, ErrUnsupported
+}
+
+func (s *SecretManager) GenerateEvmAddresses(addressRange types.Range, accountIndex uint32, _ string, options *types.IGenerateAddressOptions) ([]string, error) {
+	addresses := make([]string, 0, addressRange.End-min(addressRange.Start, addressRange.End))
+
+	for addressIndex := addressRange.Start; addressIndex < addressRange.End; addressIndex++ {
+		chain := types.NewBip44Chain(types.CoinTypeEther, accountIndex, addressIndex, options != nil && options.Internal)
+
+		var address []byte
+		err := s.withSecp256k1Key(chain, func(privateKey *secp256k1.PrivateKey) error {
+			address = keccak256(privateKey.PubKey().SerializeUncompressed()[1:])[12:]
+
+			return nil
+		})
+		if err != nil {
+			return []string{}, err
+		}
+
+		addresses = append(addresses, string(types.NewHexEncodedString(address)))
+	}
+
+	return addresses, nil
+}
+
+// GenerateEd25519Addresses generates a range of addresses, an empty bech32Hrp defaults to the HRP of the coin type
+func (s *SecretManager) GenerateEd25519Addresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) ([]string, error) {
+	bech32Hrp, err := types.ResolveBech32Hrp(coinType, bech32Hrp)
+	if err != nil {
+		return []string{}, err
+	}
+
+	addresses := make([]string, 0, addressRange.End-min(addressRange.Start, addressRange.End))
+
+	for addressIndex := addressRange.Start; addressIndex < addressRange.End; addressIndex++ {
+		chain := types.NewBip44Chain(coinType, accountIndex, addressIndex, options != nil && options.Internal)
+
+		var publicKey ed25519.PublicKey
+		err := s.withEd25519Key(chain, func(privateKey ed25519.PrivateKey) error {
+			publicKey = privateKey.Public().(ed25519.PublicKey)
+			return nil
+		})
+		if err != nil {
+			return []string{}, err
+		}
+
+		pubKeyHash := blake2b.Sum256(publicKey)
+		address, err := isc.AddressToBech32(bech32Hrp, types.Address{
+			Type:       types.AddressTypeEd25519,
+			PubKeyHash: types.NewHexEncodedString(pubKeyHash[:]),
+		})
+		if err != nil {
+			return []string{}, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+func (s *SecretManager) GenerateEd25519Address(addressIndex uint32, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) (string, error) {
+	addresses, err := s.GenerateEd25519Addresses(types.NewRange(addressIndex, addressIndex+1), accountIndex, bech32Hrp, coinType, options)
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 {
+		return "", errors.New("failed to get address")
+	}
+
+	return addresses[0], nil
+}
+
+// Ed25519PublicKey returns the Ed25519 public key of the BIP44 chain, which the native secret managers only expose with a signature
+func (s *SecretManager) Ed25519PublicKey(bip44Chain types.Bip44Chain) (ed25519.PublicKey, error) {
+	var publicKey ed25519.PublicKey
+	err := s.withEd25519Key(bip44Chain, func(privateKey ed25519.PrivateKey) error {
+		publicKey = privateKey.Public().(ed25519.PublicKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return publicKey, nil
+}
+
+func (s *SecretManager) SignTransactionEssence(txEssence types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Ed25519Signature, error) {
+	message, err := txEssence.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var signature *types.Ed25519Signature
+	err = s.withEd25519Key(bip44Chain, func(privateKey ed25519.PrivateKey) error {
+		signature = &types.Ed25519Signature{
+			PublicKey: string(types.NewHexEncodedString(privateKey.Public().(ed25519.PublicKey))),
+			Signature: string(types.NewHexEncodedString(ed25519.Sign(privateKey, message))),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// SignSecp256k1Ecdsa signs the keccak256 hash of the message with the secp256k1 key of the BIP44 chain, usually of coin type CoinTypeEther
+func (s *SecretManager) SignSecp256k1Ecdsa(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error) {
+	data, err := message.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var signature *types.Secp256k1EcdsaSignature
+	err = s.withSecp256k1Key(bip44Chain, func(privateKey *secp256k1.PrivateKey) error {
+		signature = &types.Secp256k1EcdsaSignature{
+			PublicKey: string(types.NewHexEncodedString(privateKey.PubKey().SerializeCompressed())),
+			Signature: string(types.NewHexEncodedString(signSecp256k1(privateKey, keccak256(data)))),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// withEd25519Key derives the key of the chain with all levels hardened, as the native secret managers do
+func (s *SecretManager) withEd25519Key(chain types.Bip44Chain, f func(privateKey ed25519.PrivateKey) error) error {
+	path := []uint32{44 | Hardened, chain.CoinType | Hardened, chain.Account | Hardened, chain.Change | Hardened, chain.AddressIndex | Hardened}
+
+	return s.withSeed(func(seed []byte) error {
+		keySeed, err := DeriveEd25519(seed, path)
+		if err != nil {
+			return err
+		}
+		defer memguard.WipeBytes(keySeed)
+
+		privateKey := ed25519.NewKeyFromSeed(keySeed)
+		defer memguard.WipeBytes(privateKey)
+
+		return f(privateKey)
+	})
+}
+
+// withSecp256k1Key derives the key of the chain with the change and address index not hardened, as specified by BIP44
+func (s *SecretManager) withSecp256k1Key(chain types.Bip44Chain, f func(privateKey *secp256k1.PrivateKey) error) error {
+	path := []uint32{44 | Hardened, chain.CoinType | Hardened, chain.Account | Hardened, chain.Change, chain.AddressIndex}
+
+	return s.withSeed(func(seed []byte) error {
+		privateKey, err := deriveSecp256k1(seed, path)
+		if err != nil {
+			return err
+		}
+		defer privateKey.Zero()
+
+		return f(privateKey)
+	})
+}
+
+func (s *SecretManager) withSeed(f func(seed []byte) error) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.seed == nil {
+		return ErrSecretManagerClosed
+	}
+
+	buffer, err := s.seed.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	return f(buffer.Bytes())
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+
+	return hash.Sum(nil)
+}
@@ -0,0 +1,245 @@
+package wasp_wallet_sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/awnumar/memguard"
+)
+
+// Domain of a message bus call, the kind of native object the method is called on
+type Domain string
+
+const (
+	DomainUtils         Domain = "utils"
+	DomainClient        Domain = "client"
+	DomainWallet        Domain = "wallet"
+	DomainSecretManager Domain = "secretManager"
+)
+
+// Next performs the native call, or invokes the next interceptor.
+// It returns a *MethodError for error responses of the native library. Errors are redacted with RedactSecrets.
+type Next func(ctx context.Context) error
+
+// Interceptor wraps message bus calls, e.g. for timing, metrics, tracing or retries.
+// Interceptors only get the method name, the payload is available redacted with RedactedPayload.
+// An interceptor may call next multiple times, or not at all by returning an error.
+type Interceptor func(ctx context.Context, domain Domain, method string, next Next) error
+
+// MethodError is an error response of the native library
+type MethodError struct {
+	Domain Domain
+	Method string
+
+	// Type of the error, e.g. "client" or "wallet"
+	Type    string
+	Message string
+}
+
+func (e *MethodError) Error() string {
+	return fmt.Sprintf("%s.%s: %s", e.Domain, e.Method, e.Message)
+}
+
+// secretPayloadKeys are the JSON keys whose values RedactedPayload replaces, compared lowercase
+var secretPayloadKeys = map[string]bool{
+	"mnemonic":        true,
+	"password":        true,
+	"currentpassword": true,
+	"newpassword":     true,
+	"seed":            true,
+	"hexseed":         true,
+	"privatekey":      true,
+	"secretkey":       true,
+}
+
+type payloadContextKey struct{}
+
+// Use appends interceptors to the chain, the first one added is the outermost
+func (i *IOTASDK) Use(interceptors ...Interceptor) {
+	i.interceptorsMutex.Lock()
+	defer i.interceptorsMutex.Unlock()
+
+	var chain []Interceptor
+	if current := i.interceptors.Load(); current != nil {
+		chain = append(chain, *current...)
+	}
+	chain = append(chain, interceptors...)
+
+	i.interceptors.Store(&chain)
+}
+
+// RedactedPayload returns the JSON payload of the call an interceptor is invoked for.
+// Values of secret fields are replaced, and strings are redacted with RedactSecrets.
+func RedactedPayload(ctx context.Context) (json.RawMessage, error) {
+	method, ok := ctx.Value(payloadContextKey{}).(payload)
+	if !ok {
+		return nil, errors.New("no message bus call in context")
+	}
+
+	data, err := json.Marshal(method.value)
+	defer memguard.WipeBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(redactPayloadValue(value, RedactSecrets))
+}
+
+// payload wraps the method in the context, so it's only accessible through RedactedPayload
+type payload struct {
+	value any
+}
+
+// redactPayloadValue replaces the values of secret fields, and redacts all strings with redactString
+func redactPayloadValue(value any, redactString func(string) string) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if secretPayloadKeys[strings.ToLower(key)] {
+				value[key] = redacted
+			} else {
+				value[key] = redactPayloadValue(field, redactString)
+			}
+		}
+		return value
+	case []any:
+		for index, element := range value {
+			value[index] = redactPayloadValue(element, redactString)
+		}
+		return value
+	case string:
+		return redactString(value)
+	default:
+		return value
+	}
+}
+
+// NewRetryInterceptor retries calls that failed with a retryable error, up to maxAttempts calls in total.
+// The backoff doubles with every attempt. Only idempotent methods should be considered retryable.
+func NewRetryInterceptor(maxAttempts int, backoff time.Duration, retryable func(domain Domain, method string, err error) bool) Interceptor {
+	return func(ctx context.Context, domain Domain, method string, next Next) error {
+		delay := backoff
+
+		for attempt := 1; ; attempt++ {
+			err := next(ctx)
+			if err == nil || attempt >= maxAttempts || !retryable(domain, method, err) {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return errors.Join(err, ctx.Err())
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+	}
+}
+
+// interceptCall runs the call through the interceptor chain. The response of the last invocation is returned,
+// unless an interceptor failed with an error other than the error response itself.
+func (i *IOTASDK) interceptCall(ctx context.Context, domain Domain, method any, call func() ([]byte, func(), error)) ([]byte, func(), error) {
+	current := i.interceptors.Load()
+	if current == nil || len(*current) == 0 {
+		return call()
+	}
+	chain := *current
+	name := methodName(method)
+
+	var response []byte
+	free := func() {}
+
+	// Interceptors get the redacted error of the last invocation, the caller gets the original one
+	var callErr, redactedErr error
+
+	invoke := func(context.Context) error {
+		free()
+		response, free, callErr = call()
+		redactedErr = nil
+
+		if callErr != nil {
+			redactedErr = redactError(callErr)
+			return redactedErr
+		}
+
+		if methodErr := methodErrorOf(response, domain, name); methodErr != nil {
+			redactedMethodErr := *methodErr
+			redactedMethodErr.Message = RedactSecrets(methodErr.Message)
+			redactedErr = &redactedMethodErr
+		}
+
+		return redactedErr
+	}
+
+	next := Next(invoke)
+	for index := len(chain) - 1; index >= 0; index-- {
+		interceptor, inner := chain[index], next
+		next = func(ctx context.Context) error {
+			return interceptor(ctx, domain, name, inner)
+		}
+	}
+
+	err := next(context.WithValue(ctx, payloadContextKey{}, payload{value: method}))
+
+	switch {
+	case callErr != nil:
+		free()
+		return nil, func() {}, callErr
+	case err != nil && err != redactedErr:
+		free()
+		return nil, func() {}, err
+	case response == nil:
+		// An interceptor returned without error, but didn't call next
+		return nil, func() {}, fmt.Errorf("%s.%s: not called by interceptor", domain, name)
+	default:
+		return response, free, nil
+	}
+}
+
+// redactError returns the error with RedactSecrets applied, errors without secrets are returned as they are
+func redactError(err error) error {
+	message := err.Error()
+	if redactedMessage := RedactSecrets(message); redactedMessage != message {
+		return errors.New(redactedMessage)
+	}
+
+	return err
+}
+
+// methodErrorOf returns the error of an error response, it's nil for other responses.
+// The payload is only decoded for error responses, others may contain secrets.
+func methodErrorOf(response []byte, domain Domain, method string) *MethodError {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(response, &envelope); err != nil || envelope.Type != "error" {
+		return nil
+	}
+
+	var errorEnvelope struct {
+		Payload struct {
+			Type  string `json:"type"`
+			Error string `json:"error"`
+		} `json:"payload"`
+	}
+
+	if err := json.Unmarshal(response, &errorEnvelope); err != nil {
+		return nil
+	}
+
+	return &MethodError{Domain: domain, Method: method, Type: errorEnvelope.Payload.Type, Message: errorEnvelope.Payload.Error}
+}
@@ -0,0 +1,82 @@
+package isc
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const addressIdLength = 32
+
+// AddressFromBech32 decodes a bech32 encoded L1 address and returns its human readable part
+func AddressFromBech32(s string) (string, types.Address, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return "", types.Address{}, err
+	}
+
+	address, err := addressFromBytes(data)
+	if err != nil {
+		return "", types.Address{}, err
+	}
+
+	return hrp, address, nil
+}
+
+// AddressToBech32 encodes an L1 address with the human readable part of the network
+func AddressToBech32(hrp string, address types.Address) (string, error) {
+	data, err := addressBytes(address)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32Encode(hrp, data)
+}
+
+// addressBytes serializes an address as its type followed by its ID
+func addressBytes(address types.Address) ([]byte, error) {
+	var id types.HexEncodedString
+	switch address.Type {
+	case types.AddressTypeEd25519:
+		id = address.PubKeyHash
+	case types.AddressTypeAlias:
+		id = address.AliasId
+	case types.AddressTypeNft:
+		id = address.NftId
+	default:
+		return nil, fmt.Errorf("unknown address type %d", address.Type)
+	}
+
+	idBytes, err := id.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(idBytes) != addressIdLength {
+		return nil, fmt.Errorf("invalid address %s: expected %d bytes, got %d", id, addressIdLength, len(idBytes))
+	}
+
+	return append([]byte{byte(address.Type)}, idBytes...), nil
+}
+
+func addressFromBytes(data []byte) (types.Address, error) {
+	if len(data) != addressIdLength+1 {
+		return types.Address{}, fmt.Errorf("invalid address length %d", len(data))
+	}
+
+	address := types.Address{Type: types.AddressType(data[0])}
+	id := types.NewHexEncodedString(data[1:])
+
+	switch address.Type {
+	case types.AddressTypeEd25519:
+		address.PubKeyHash = id
+	case types.AddressTypeAlias:
+		address.AliasId = id
+	case types.AddressTypeNft:
+		address.NftId = id
+	default:
+		return types.Address{}, fmt.Errorf("unknown address type %d", address.Type)
+	}
+
+	return address, nil
+}
@@ -0,0 +1,311 @@
+package isc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type AgentIDKind byte
+
+const (
+	AgentIDKindNil AgentIDKind = iota
+	AgentIDKindAddress
+	AgentIDKindContract
+	AgentIDKindEthereumAddress
+)
+
+const EthereumAddressLength = 20
+
+// nilAgentIDString is the string representation of the nil agent ID
+const nilAgentIDString = "-"
+
+var ErrInvalidAgentID = errors.New("invalid agent ID")
+
+// EthereumAddress is the address of an EVM account
+type EthereumAddress [EthereumAddressLength]byte
+
+// AgentID identifies the owner of funds on a chain
+type AgentID interface {
+	Kind() AgentIDKind
+
+	// Bytes returns the serialization used in request parameters
+	Bytes() []byte
+
+	// Bech32 formats the agent ID as Wasp does, using the bech32 HRP of the L1 network for addresses and chain IDs
+	Bech32(hrp string) string
+}
+
+var (
+	_ AgentID = (*NilAgentID)(nil)
+	_ AgentID = (*AddressAgentID)(nil)
+	_ AgentID = (*ContractAgentID)(nil)
+	_ AgentID = (*EthereumAddressAgentID)(nil)
+)
+
+// AgentIDFromString parses the string representation of an agent ID:
+// an L1 bech32 address, "<hname>@<chain ID>", "0x<EVM address>@<chain ID>" or "-" for the nil agent ID.
+// It returns the bech32 HRP used in the string, which is empty for the nil agent ID.
+func AgentIDFromString(s string) (string, AgentID, error) {
+	if s == nilAgentIDString {
+		return "", &NilAgentID{}, nil
+	}
+
+	agent, chain, isOnChain := strings.Cut(s, "@")
+	if !isOnChain {
+		hrp, address, err := AddressFromBech32(s)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+		}
+
+		return hrp, NewAddressAgentID(address), nil
+	}
+
+	hrp, chainID, err := ChainIDFromBech32(chain)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: invalid chain ID: %v", ErrInvalidAgentID, err)
+	}
+
+	if strings.HasPrefix(agent, "0x") {
+		ethereumAddress, err := EthereumAddressFromHex(agent)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+		}
+
+		return hrp, NewEthereumAddressAgentID(chainID, ethereumAddress), nil
+	}
+
+	hname, err := ParseHname(agent)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+	}
+
+	return hrp, NewContractAgentID(chainID, hname), nil
+}
+
+// AgentIDFromBytes decodes the serialization of an agent ID
+func AgentIDFromBytes(data []byte) (AgentID, error) {
+	r := newReader(data)
+
+	var agentID AgentID
+	switch kind := AgentIDKind(r.readByte()); kind {
+	case AgentIDKindNil:
+		agentID = &NilAgentID{}
+	case AgentIDKindAddress:
+		address, err := addressFromBytes(r.readN(addressIdLength + 1))
+		if err != nil && r.err == nil {
+			r.err = err
+		}
+		agentID = NewAddressAgentID(address)
+	case AgentIDKindContract:
+		var chainID ChainID
+		copy(chainID[:], r.readN(ChainIDLength))
+		agentID = NewContractAgentID(chainID, Hname(r.readUint32()))
+	case AgentIDKindEthereumAddress:
+		var chainID ChainID
+		var ethereumAddress EthereumAddress
+		copy(chainID[:], r.readN(ChainIDLength))
+		copy(ethereumAddress[:], r.readN(EthereumAddressLength))
+		agentID = NewEthereumAddressAgentID(chainID, ethereumAddress)
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %d", ErrInvalidAgentID, kind)
+	}
+
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+	}
+
+	return agentID, nil
+}
+
+// AgentIDFromBech32Address returns the agent ID of an L1 address, e.g. as returned by GenerateEd25519Addresses
+func AgentIDFromBech32Address(bech32Address string) (*AddressAgentID, error) {
+	_, address, err := AddressFromBech32(bech32Address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+	}
+
+	return NewAddressAgentID(address), nil
+}
+
+// AgentIDFromEvmAddress returns the agent ID of an EVM account on the chain, e.g. of an address returned by GenerateEvmAddresses
+func AgentIDFromEvmAddress(chainID ChainID, evmAddress string) (*EthereumAddressAgentID, error) {
+	ethereumAddress, err := EthereumAddressFromHex(evmAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAgentID, err)
+	}
+
+	return NewEthereumAddressAgentID(chainID, ethereumAddress), nil
+}
+
+// AgentIDsEqual compares agent IDs by their serialization
+func AgentIDsEqual(a AgentID, b AgentID) bool {
+	return bytes.Equal(a.Bytes(), b.Bytes())
+}
+
+// NilAgentID is the agent ID owning nothing
+type NilAgentID struct{}
+
+func (a *NilAgentID) Kind() AgentIDKind {
+	return AgentIDKindNil
+}
+
+func (a *NilAgentID) Bytes() []byte {
+	return []byte{byte(AgentIDKindNil)}
+}
+
+func (a *NilAgentID) Bech32(string) string {
+	return nilAgentIDString
+}
+
+// AddressAgentID is the L2 account of an L1 address
+type AddressAgentID struct {
+	address types.Address
+}
+
+func NewAddressAgentID(address types.Address) *AddressAgentID {
+	return &AddressAgentID{address: address}
+}
+
+func (a *AddressAgentID) Kind() AgentIDKind {
+	return AgentIDKindAddress
+}
+
+func (a *AddressAgentID) Address() types.Address {
+	return a.address
+}
+
+func (a *AddressAgentID) Bytes() []byte {
+	// The address was validated when it was parsed, an invalid address serializes as its kind only
+	address, _ := addressBytes(a.address)
+
+	return append([]byte{byte(a.Kind())}, address...)
+}
+
+func (a *AddressAgentID) Bech32(hrp string) string {
+	encoded, _ := AddressToBech32(hrp, a.address)
+
+	return encoded
+}
+
+// ContractAgentID is a contract on a chain
+type ContractAgentID struct {
+	chainID ChainID
+	hname   Hname
+}
+
+func NewContractAgentID(chainID ChainID, hname Hname) *ContractAgentID {
+	return &ContractAgentID{
+		chainID: chainID,
+		hname:   hname,
+	}
+}
+
+func (a *ContractAgentID) Kind() AgentIDKind {
+	return AgentIDKindContract
+}
+
+func (a *ContractAgentID) ChainID() ChainID {
+	return a.chainID
+}
+
+func (a *ContractAgentID) Hname() Hname {
+	return a.hname
+}
+
+func (a *ContractAgentID) Bytes() []byte {
+	w := new(writer)
+	w.writeByte(byte(a.Kind()))
+	w.writeN(a.chainID[:])
+	w.writeUint32(uint32(a.hname))
+
+	return w.Bytes()
+}
+
+func (a *ContractAgentID) Bech32(hrp string) string {
+	return a.hname.String() + "@" + a.chainID.Bech32(hrp)
+}
+
+// EthereumAddressAgentID is an EVM account on a chain
+type EthereumAddressAgentID struct {
+	chainID ChainID
+	address EthereumAddress
+}
+
+func NewEthereumAddressAgentID(chainID ChainID, address EthereumAddress) *EthereumAddressAgentID {
+	return &EthereumAddressAgentID{
+		chainID: chainID,
+		address: address,
+	}
+}
+
+func (a *EthereumAddressAgentID) Kind() AgentIDKind {
+	return AgentIDKindEthereumAddress
+}
+
+func (a *EthereumAddressAgentID) ChainID() ChainID {
+	return a.chainID
+}
+
+func (a *EthereumAddressAgentID) EthereumAddress() EthereumAddress {
+	return a.address
+}
+
+func (a *EthereumAddressAgentID) Bytes() []byte {
+	w := new(writer)
+	w.writeByte(byte(a.Kind()))
+	w.writeN(a.chainID[:])
+	w.writeN(a.address[:])
+
+	return w.Bytes()
+}
+
+func (a *EthereumAddressAgentID) Bech32(hrp string) string {
+	return a.address.String() + "@" + a.chainID.Bech32(hrp)
+}
+
+// EthereumAddressFromHex parses a 0x prefixed hex encoded EVM address, the checksum casing is not verified
+func EthereumAddressFromHex(s string) (EthereumAddress, error) {
+	var address EthereumAddress
+
+	data, err := types.HexEncodedString(s).Bytes()
+	if err != nil {
+		return address, err
+	}
+
+	if len(data) != EthereumAddressLength {
+		return address, fmt.Errorf("invalid EVM address %q: expected %d bytes, got %d", s, EthereumAddressLength, len(data))
+	}
+	copy(address[:], data)
+
+	return address, nil
+}
+
+// String returns the address with EIP-55 checksum casing
+func (a EthereumAddress) String() string {
+	lower := hex.EncodeToString(a[:])
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		// Letters are uppercased if the corresponding nibble of the hash is >= 8
+		nibble := digest[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(checksummed)
+}
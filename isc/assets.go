@@ -0,0 +1,171 @@
+package isc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const (
+	NativeTokenIDLength = 38
+	NFTIDLength         = 32
+)
+
+const (
+	assetsHasBaseTokens   = 0x80
+	assetsHasNativeTokens = 0x40
+	assetsHasNFTs         = 0x20
+)
+
+type NativeTokenID [NativeTokenIDLength]byte
+
+type NFTID [NFTIDLength]byte
+
+type NativeToken struct {
+	ID     NativeTokenID
+	Amount *big.Int
+}
+
+// Assets are base tokens, native tokens and NFTs, e.g. the allowance of a request
+type Assets struct {
+	BaseTokens   uint64
+	NativeTokens []NativeToken
+	NFTs         []NFTID
+}
+
+// NativeTokenIDFromHex converts the hex encoded ID of a native token, which is the ID of its foundry
+func NativeTokenIDFromHex(tokenId types.HexEncodedString) (NativeTokenID, error) {
+	var id NativeTokenID
+
+	data, err := tokenId.Bytes()
+	if err != nil {
+		return id, err
+	}
+
+	if len(data) != NativeTokenIDLength {
+		return id, fmt.Errorf("invalid native token ID: expected %d bytes, got %d", NativeTokenIDLength, len(data))
+	}
+	copy(id[:], data)
+
+	return id, nil
+}
+
+// NFTIDFromHex converts the hex encoded ID of an NFT
+func NFTIDFromHex(nftId types.HexEncodedString) (NFTID, error) {
+	var id NFTID
+
+	data, err := nftId.Bytes()
+	if err != nil {
+		return id, err
+	}
+
+	if len(data) != NFTIDLength {
+		return id, fmt.Errorf("invalid NFT ID: expected %d bytes, got %d", NFTIDLength, len(data))
+	}
+	copy(id[:], data)
+
+	return id, nil
+}
+
+func NewAssetsBaseTokens(amount uint64) *Assets {
+	return &Assets{BaseTokens: amount}
+}
+
+func (a *Assets) IsEmpty() bool {
+	return a == nil || (a.BaseTokens == 0 && len(a.NativeTokens) == 0 && len(a.NFTs) == 0)
+}
+
+// Bytes serializes the assets with native tokens and NFTs sorted by ID, as Wasp does.
+// A nil Assets is serialized as empty assets.
+func (a *Assets) Bytes() []byte {
+	w := new(writer)
+	a.write(w)
+
+	return w.Bytes()
+}
+
+func (a *Assets) write(w *writer) {
+	var flags byte
+	if a != nil {
+		if a.BaseTokens != 0 {
+			flags |= assetsHasBaseTokens
+		}
+		if len(a.NativeTokens) != 0 {
+			flags |= assetsHasNativeTokens
+		}
+		if len(a.NFTs) != 0 {
+			flags |= assetsHasNFTs
+		}
+	}
+
+	w.writeByte(flags)
+
+	if flags&assetsHasBaseTokens != 0 {
+		w.writeAmount64(a.BaseTokens)
+	}
+
+	if flags&assetsHasNativeTokens != 0 {
+		nativeTokens := make([]NativeToken, len(a.NativeTokens))
+		copy(nativeTokens, a.NativeTokens)
+		sort.Slice(nativeTokens, func(i, j int) bool {
+			return bytes.Compare(nativeTokens[i].ID[:], nativeTokens[j].ID[:]) < 0
+		})
+
+		w.writeSize(len(nativeTokens))
+		for _, nativeToken := range nativeTokens {
+			amount := nativeToken.Amount
+			if amount == nil {
+				amount = new(big.Int)
+			}
+
+			w.writeN(nativeToken.ID[:])
+			w.writeUint256(amount)
+		}
+	}
+
+	if flags&assetsHasNFTs != 0 {
+		nfts := make([]NFTID, len(a.NFTs))
+		copy(nfts, a.NFTs)
+		sort.Slice(nfts, func(i, j int) bool {
+			return bytes.Compare(nfts[i][:], nfts[j][:]) < 0
+		})
+
+		w.writeSize(len(nfts))
+		for _, nft := range nfts {
+			w.writeN(nft[:])
+		}
+	}
+}
+
+func readAssets(r *reader) *Assets {
+	assets := new(Assets)
+
+	flags := r.readByte()
+	if flags&assetsHasBaseTokens != 0 {
+		assets.BaseTokens = r.readUleb128()
+	}
+
+	if flags&assetsHasNativeTokens != 0 {
+		size := r.readSize()
+		for i := 0; i < size && r.err == nil; i++ {
+			var nativeToken NativeToken
+			copy(nativeToken.ID[:], r.readN(NativeTokenIDLength))
+			nativeToken.Amount = r.readUint256()
+			assets.NativeTokens = append(assets.NativeTokens, nativeToken)
+		}
+	}
+
+	if flags&assetsHasNFTs != 0 {
+		size := r.readSize()
+		for i := 0; i < size && r.err == nil; i++ {
+			var nft NFTID
+			copy(nft[:], r.readN(NFTIDLength))
+			assets.NFTs = append(assets.NFTs, nft)
+		}
+	}
+
+	return assets
+}
@@ -0,0 +1,96 @@
+package isc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const ChainIDLength = 32
+
+// ChainID identifies a Wasp chain, it's the ID of the alias output anchoring the chain on L1
+type ChainID [ChainIDLength]byte
+
+// ChainIDFromAliasId converts the hex encoded ID of the chain's alias
+func ChainIDFromAliasId(aliasId types.HexEncodedString) (ChainID, error) {
+	data, err := aliasId.Bytes()
+	if err != nil {
+		return ChainID{}, err
+	}
+
+	return chainIDFromBytes(data)
+}
+
+// ChainIDFromBech32 decodes the bech32 alias address of a chain and returns its human readable part
+func ChainIDFromBech32(s string) (string, ChainID, error) {
+	hrp, address, err := AddressFromBech32(s)
+	if err != nil {
+		return "", ChainID{}, err
+	}
+
+	chainID, err := ChainIDFromAddress(address)
+	if err != nil {
+		return "", ChainID{}, err
+	}
+
+	return hrp, chainID, nil
+}
+
+// ChainIDFromString parses a chain ID given either as bech32 alias address or as 0x prefixed hex alias ID.
+// The returned human readable part is empty for hex encoded chain IDs.
+func ChainIDFromString(s string) (string, ChainID, error) {
+	if strings.HasPrefix(s, "0x") {
+		chainID, err := ChainIDFromAliasId(types.HexEncodedString(s))
+		return "", chainID, err
+	}
+
+	return ChainIDFromBech32(s)
+}
+
+// ChainIDFromAddress converts the alias address of a chain
+func ChainIDFromAddress(address types.Address) (ChainID, error) {
+	if address.Type != types.AddressTypeAlias {
+		return ChainID{}, fmt.Errorf("address of type %d is not an alias address", address.Type)
+	}
+
+	return ChainIDFromAliasId(address.AliasId)
+}
+
+func chainIDFromBytes(data []byte) (ChainID, error) {
+	var chainID ChainID
+	if len(data) != ChainIDLength {
+		return chainID, fmt.Errorf("invalid chain ID: expected %d bytes, got %d", ChainIDLength, len(data))
+	}
+
+	copy(chainID[:], data)
+
+	return chainID, nil
+}
+
+// AliasId returns the hex encoded ID of the alias anchoring the chain
+func (c ChainID) AliasId() types.HexEncodedString {
+	return types.NewHexEncodedString(c[:])
+}
+
+// Address returns the alias address of the chain, which is the target of L1 requests
+func (c ChainID) Address() types.Address {
+	return types.Address{
+		Type:    types.AddressTypeAlias,
+		AliasId: c.AliasId(),
+	}
+}
+
+// Bech32 encodes the alias address of the chain, the usual representation of a chain ID
+func (c ChainID) Bech32(hrp string) string {
+	data := append([]byte{byte(types.AddressTypeAlias)}, c[:]...)
+
+	// Encoding can't fail, as the data is always valid
+	encoded, _ := bech32Encode(hrp, data)
+
+	return encoded
+}
+
+func (c ChainID) String() string {
+	return string(c.AliasId())
+}
@@ -0,0 +1,169 @@
+package isc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// writer implements the binary encoding used by Wasp: sizes and amounts are ULEB128 encoded, fixed size integers are little endian
+type writer struct {
+	buf bytes.Buffer
+}
+
+func (w *writer) writeByte(value byte) *writer {
+	w.buf.WriteByte(value)
+	return w
+}
+
+func (w *writer) writeN(value []byte) *writer {
+	w.buf.Write(value)
+	return w
+}
+
+func (w *writer) writeUint32(value uint32) *writer {
+	w.buf.Write(binary.LittleEndian.AppendUint32(nil, value))
+	return w
+}
+
+func (w *writer) writeUint64(value uint64) *writer {
+	w.buf.Write(binary.LittleEndian.AppendUint64(nil, value))
+	return w
+}
+
+func (w *writer) writeSize(value int) *writer {
+	return w.writeUleb128(uint64(value))
+}
+
+func (w *writer) writeAmount64(value uint64) *writer {
+	return w.writeUleb128(value)
+}
+
+// writeGas64 shifts the gas by one, so the common "no limit" value math.MaxUint64 is encoded in a single byte
+func (w *writer) writeGas64(value uint64) *writer {
+	return w.writeUleb128(value + 1)
+}
+
+func (w *writer) writeBytes(value []byte) *writer {
+	return w.writeSize(len(value)).writeN(value)
+}
+
+func (w *writer) writeUint256(value *big.Int) *writer {
+	return w.writeBytes(value.Bytes())
+}
+
+func (w *writer) writeUleb128(value uint64) *writer {
+	w.buf.Write(binary.AppendUvarint(nil, value))
+	return w
+}
+
+func (w *writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+var errShortBuffer = errors.New("unexpected end of data")
+
+// reader decodes the encoding of writer
+type reader struct {
+	data []byte
+	err  error
+}
+
+func newReader(data []byte) *reader {
+	return &reader{data: data}
+}
+
+func (r *reader) readByte() byte {
+	value := r.readN(1)
+	if value == nil {
+		return 0
+	}
+
+	return value[0]
+}
+
+func (r *reader) readN(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if length > len(r.data) {
+		r.err = errShortBuffer
+		return nil
+	}
+
+	value := r.data[:length]
+	r.data = r.data[length:]
+
+	return value
+}
+
+func (r *reader) readUint32() uint32 {
+	value := r.readN(4)
+	if value == nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint32(value)
+}
+
+func (r *reader) readUint64() uint64 {
+	value := r.readN(8)
+	if value == nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(value)
+}
+
+func (r *reader) readUleb128() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, read := binary.Uvarint(r.data)
+	if read <= 0 {
+		r.err = fmt.Errorf("invalid ULEB128 value")
+		return 0
+	}
+	r.data = r.data[read:]
+
+	return value
+}
+
+func (r *reader) readSize() int {
+	size := r.readUleb128()
+	if r.err == nil && size > uint64(len(r.data)) {
+		r.err = errShortBuffer
+		return 0
+	}
+
+	return int(size)
+}
+
+func (r *reader) readGas64() uint64 {
+	return r.readUleb128() - 1
+}
+
+func (r *reader) readBytes() []byte {
+	return r.readN(r.readSize())
+}
+
+func (r *reader) readUint256() *big.Int {
+	return new(big.Int).SetBytes(r.readBytes())
+}
+
+// done returns the first error, or an error if not all data was consumed
+func (r *reader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.data) > 0 {
+		return fmt.Errorf("%d unexpected trailing bytes", len(r.data))
+	}
+
+	return nil
+}
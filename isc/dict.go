@@ -0,0 +1,39 @@
+package isc
+
+import "sort"
+
+// Dict holds the parameters of a request
+type Dict map[string][]byte
+
+// Bytes serializes the dict with its keys sorted, as Wasp does
+func (d Dict) Bytes() []byte {
+	w := new(writer)
+	d.write(w)
+
+	return w.Bytes()
+}
+
+func (d Dict) write(w *writer) {
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w.writeSize(len(keys))
+	for _, key := range keys {
+		w.writeBytes([]byte(key))
+		w.writeBytes(d[key])
+	}
+}
+
+func readDict(r *reader) Dict {
+	size := r.readSize()
+	d := make(Dict, size)
+	for i := 0; i < size && r.err == nil; i++ {
+		key := r.readBytes()
+		d[string(key)] = r.readBytes()
+	}
+
+	return d
+}
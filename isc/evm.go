@@ -0,0 +1,335 @@
+package isc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// MagicContractAddress is the address of the ISC magic contract (ISCSandbox) on every chain
+var MagicContractAddress = EthereumAddress{0x10, 0x74}
+
+// magicSendSignature is the canonical signature of ISCSandbox.send(L1Address, ISCAssets, bool, ISCSendMetadata, ISCSendOptions)
+const magicSendSignature = "send((bytes),(uint64,((bytes),uint256)[],bytes32[]),bool,(uint32,uint32,((bytes,bytes)[]),(uint64,((bytes),uint256)[],bytes32[]),uint64),(int64,(int64,(bytes))))"
+
+// Secp256k1Signer signs messages with the secp256k1 key of a BIP44 chain, as Wallet and SecretManager do
+type Secp256k1Signer interface {
+	SignSecp256k1Ecdsa(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error)
+}
+
+var (
+	_ Secp256k1Signer = (*wasp_wallet_sdk.Wallet)(nil)
+	_ Secp256k1Signer = (*wasp_wallet_sdk.SecretManager)(nil)
+)
+
+// NewMagicSendCallData builds the call of ISCSandbox.send, which sends assets of the caller's L2 account to an L1 address.
+// The assets have to cover the storage deposit of the L1 output, no metadata or send options are attached.
+func NewMagicSendCallData(target types.Address, assets *Assets) ([]byte, error) {
+	targetBytes, err := addressBytes(target)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyAssets := abiAssets(nil)
+	metadata := abiTuple{abiUint(0), abiUint(0), abiTuple{abiArray{}}, emptyAssets, abiUint(0)}
+	options := abiTuple{abiUint(0), abiTuple{abiUint(0), abiTuple{abiBytes{}}}}
+
+	arguments := abiTuple{abiTuple{abiBytes(targetBytes)}, abiAssets(assets), abiUint(0), metadata, options}
+
+	return append(keccak256([]byte(magicSendSignature))[:4], arguments.encode()...), nil
+}
+
+func abiAssets(assets *Assets) abiTuple {
+	if assets == nil {
+		assets = &Assets{}
+	}
+
+	nativeTokens := make(abiArray, 0, len(assets.NativeTokens))
+	for _, token := range assets.NativeTokens {
+		nativeTokens = append(nativeTokens, abiTuple{abiTuple{abiBytes(token.ID[:])}, abiBigUint(token.Amount)})
+	}
+
+	nfts := make(abiArray, 0, len(assets.NFTs))
+	for _, nft := range assets.NFTs {
+		nfts = append(nfts, abiWord(nft[:]))
+	}
+
+	return abiTuple{abiUint(assets.BaseTokens), nativeTokens, nfts}
+}
+
+// abiValue is a value in the contract ABI encoding, see https://docs.soliditylang.org/en/latest/abi-spec.html
+type abiValue interface {
+	dynamic() bool
+	encode() []byte
+}
+
+// abiWord is a static 32 byte value, e.g. an integer or bytes32
+type abiWord []byte
+
+func abiUint(value uint64) abiWord {
+	return binary.BigEndian.AppendUint64(make([]byte, 24), value)
+}
+
+func abiBigUint(value *big.Int) abiWord {
+	if value == nil {
+		return abiUint(0)
+	}
+
+	return value.FillBytes(make([]byte, 32))
+}
+
+func (w abiWord) dynamic() bool {
+	return false
+}
+
+func (w abiWord) encode() []byte {
+	return append([]byte(w), make([]byte, 32-len(w))...)
+}
+
+// abiBytes is a dynamically sized byte array
+type abiBytes []byte
+
+func (b abiBytes) dynamic() bool {
+	return true
+}
+
+func (b abiBytes) encode() []byte {
+	padded := make([]byte, (len(b)+31)/32*32)
+	copy(padded, b)
+
+	return append(abiUint(uint64(len(b))), padded...)
+}
+
+// abiTuple is a struct, it's static if all its components are
+type abiTuple []abiValue
+
+func (t abiTuple) dynamic() bool {
+	for _, value := range t {
+		if value.dynamic() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encode writes the static components and the offsets of the dynamic ones, followed by the dynamic components
+func (t abiTuple) encode() []byte {
+	encoded := make([][]byte, len(t))
+	headSize := 0
+	for i, value := range t {
+		encoded[i] = value.encode()
+		if value.dynamic() {
+			headSize += 32
+		} else {
+			headSize += len(encoded[i])
+		}
+	}
+
+	var head, tail []byte
+	for i, value := range t {
+		if !value.dynamic() {
+			head = append(head, encoded[i]...)
+			continue
+		}
+
+		head = append(head, abiUint(uint64(headSize+len(tail)))...)
+		tail = append(tail, encoded[i]...)
+	}
+
+	return append(head, tail...)
+}
+
+// abiArray is a dynamically sized array, encoded as its length followed by the tuple of its elements
+type abiArray []abiValue
+
+func (a abiArray) dynamic() bool {
+	return true
+}
+
+func (a abiArray) encode() []byte {
+	return append(abiUint(uint64(len(a))), abiTuple(a).encode()...)
+}
+
+// EVMTransaction is a legacy transaction with replay protection (EIP-155), e.g. a call of the ISC magic contract
+type EVMTransaction struct {
+	// EVMChainID is the chain ID of the EVM, as returned by eth_chainId
+	EVMChainID uint64
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	To         EthereumAddress
+	Value      *big.Int
+	Data       []byte
+}
+
+// SigningBytes returns the RLP encoding of the transaction as specified by EIP-155, its keccak256 hash is signed
+func (t *EVMTransaction) SigningBytes() []byte {
+	return rlpList(t.rlpFields(rlpUint(t.EVMChainID), rlpUint(0), rlpUint(0))...)
+}
+
+func (t *EVMTransaction) rlpFields(signature ...[]byte) [][]byte {
+	return append([][]byte{
+		rlpUint(t.Nonce),
+		rlpBigUint(t.GasPrice),
+		rlpUint(t.Gas),
+		rlpBytes(t.To[:]),
+		rlpBigUint(t.Value),
+		rlpBytes(t.Data),
+	}, signature...)
+}
+
+// Sign signs the transaction with the secp256k1 key of the BIP44 chain, usually of coin type CoinTypeEther
+func (t *EVMTransaction) Sign(signer Secp256k1Signer, bip44Chain types.Bip44Chain) (*SignedEVMTransaction, error) {
+	signature, err := signer.SignSecp256k1Ecdsa(types.NewHexEncodedString(t.SigningBytes()), bip44Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := types.HexEncodedString(signature.Signature).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return t.WithSignature(signatureBytes)
+}
+
+// WithSignature attaches a recoverable signature r || s || v created elsewhere, the sender is recovered from it
+func (t *EVMTransaction) WithSignature(signature []byte) (*SignedEVMTransaction, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("%w: expected 65 bytes, got %d", ErrInvalidSignature, len(signature))
+	}
+
+	recoveryID := signature[64]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 1 {
+		return nil, fmt.Errorf("%w: invalid recovery ID %d", ErrInvalidSignature, signature[64])
+	}
+
+	// The compact format of the library starts with the recovery code
+	compact := append([]byte{27 + recoveryID}, signature[:64]...)
+	publicKey, _, err := ecdsa.RecoverCompact(compact, keccak256(t.SigningBytes()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return &SignedEVMTransaction{
+		EVMTransaction: *t,
+		r:              append([]byte(nil), signature[:32]...),
+		s:              append([]byte(nil), signature[32:64]...),
+		recoveryID:     recoveryID,
+		sender:         ethereumAddressFromPublicKey(publicKey),
+	}, nil
+}
+
+// ethereumAddressFromPublicKey returns the EVM address of a secp256k1 public key
+func ethereumAddressFromPublicKey(publicKey *secp256k1.PublicKey) EthereumAddress {
+	var address EthereumAddress
+	copy(address[:], keccak256(publicKey.SerializeUncompressed()[1:])[12:])
+
+	return address
+}
+
+// SignedEVMTransaction is an EVM transaction ready to be sent with eth_sendRawTransaction
+type SignedEVMTransaction struct {
+	EVMTransaction
+
+	r, s       []byte
+	recoveryID byte
+	sender     EthereumAddress
+}
+
+// Bytes returns the RLP encoding of the signed transaction
+func (s *SignedEVMTransaction) Bytes() []byte {
+	v := new(big.Int).SetUint64(s.EVMChainID)
+	v.Mul(v, big.NewInt(2)).Add(v, big.NewInt(35+int64(s.recoveryID)))
+
+	return rlpList(s.rlpFields(rlpBigUint(v), rlpBytes(trimLeadingZeros(s.r)), rlpBytes(trimLeadingZeros(s.s)))...)
+}
+
+// Hash is the transaction hash, the keccak256 hash of the signed transaction
+func (s *SignedEVMTransaction) Hash() [32]byte {
+	var hash [32]byte
+	copy(hash[:], keccak256(s.Bytes()))
+
+	return hash
+}
+
+// ID is the request ID of the transaction on the chain, its hash followed by the output index 0
+func (s *SignedEVMTransaction) ID() RequestID {
+	var id RequestID
+
+	hash := s.Hash()
+	copy(id[:], hash[:])
+
+	return id
+}
+
+// Sender is the EVM address recovered from the signature, which is charged for the transaction
+func (s *SignedEVMTransaction) Sender() EthereumAddress {
+	return s.sender
+}
+
+// rlpBytes encodes a byte string in the recursive length prefix encoding of Ethereum
+func rlpBytes(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(rlpHeader(0x80, len(data)), data...)
+}
+
+func rlpUint(value uint64) []byte {
+	return rlpBytes(trimLeadingZeros(binary.BigEndian.AppendUint64(nil, value)))
+}
+
+func rlpBigUint(value *big.Int) []byte {
+	if value == nil {
+		return rlpBytes(nil)
+	}
+
+	return rlpBytes(value.Bytes())
+}
+
+func rlpList(items ...[]byte) []byte {
+	var payload []byte
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+
+	return append(rlpHeader(0xc0, len(payload)), payload...)
+}
+
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+
+	sizeBytes := trimLeadingZeros(binary.BigEndian.AppendUint64(nil, uint64(size)))
+
+	return append([]byte{offset + 55 + byte(len(sizeBytes))}, sizeBytes...)
+}
+
+func trimLeadingZeros(data []byte) []byte {
+	for len(data) > 0 && data[0] == 0 {
+		data = data[1:]
+	}
+
+	return data
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+
+	return hash.Sum(nil)
+}
@@ -0,0 +1,56 @@
+package isc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Hname is the 4 byte hash of a contract or entry point name
+type Hname uint32
+
+// Hnames of the core contract entry points used by the SDK
+var (
+	HnameAccounts = HnameFromString("accounts")
+	HnameEVM      = HnameFromString("evm")
+	HnameRoot     = HnameFromString("root")
+
+	FuncDeposit             = HnameFromString("deposit")
+	FuncTransferAllowanceTo = HnameFromString("transferAllowanceTo")
+	FuncWithdraw            = HnameFromString("withdraw")
+)
+
+// HnameFromString computes the hname of a name, as Wasp does
+func HnameFromString(name string) Hname {
+	hash := blake2b.Sum256([]byte(name))
+
+	// 0 and MaxUint32 are reserved, the next bytes of the hash are used instead
+	for offset := 0; offset+4 <= len(hash); offset += 4 {
+		hname := Hname(binary.LittleEndian.Uint32(hash[offset : offset+4]))
+		if hname != 0 && hname != ^Hname(0) {
+			return hname
+		}
+	}
+
+	panic("unreachable: hash consists of reserved hnames only")
+}
+
+// ParseHname parses the hex representation of an hname, as returned by String
+func ParseHname(s string) (Hname, error) {
+	value, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hname %q: %w", s, err)
+	}
+
+	return Hname(value), nil
+}
+
+func (h Hname) Bytes() []byte {
+	return binary.LittleEndian.AppendUint32(nil, uint32(h))
+}
+
+func (h Hname) String() string {
+	return fmt.Sprintf("%08x", uint32(h))
+}
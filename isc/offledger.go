@@ -0,0 +1,194 @@
+package isc
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// requestKindOffLedgerISC prefixes the serialization of off-ledger requests
+const requestKindOffLedgerISC byte = 1
+
+const RequestIDLength = 34
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Ed25519Signer signs messages with the Ed25519 key of a BIP44 chain, as Wallet and SecretManager do
+type Ed25519Signer interface {
+	SignTransactionEssence(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Ed25519Signature, error)
+}
+
+var (
+	_ Ed25519Signer = (*wasp_wallet_sdk.Wallet)(nil)
+	_ Ed25519Signer = (*wasp_wallet_sdk.SecretManager)(nil)
+)
+
+// RequestID identifies a request on a chain, for off-ledger requests it's derived from the signed request
+type RequestID [RequestIDLength]byte
+
+func (r RequestID) String() string {
+	return string(types.NewHexEncodedString(r[:]))
+}
+
+// OffLedgerRequest is a request posted to a Wasp node directly instead of being sent on L1.
+// The nonce has to be higher than the nonce of the sender's previous off-ledger request on the chain.
+type OffLedgerRequest struct {
+	ChainID    ChainID
+	Contract   Hname
+	EntryPoint Hname
+	Params     Dict
+	Nonce      uint64
+	GasBudget  uint64
+
+	// Allowance the target contract may take from the sender's L2 account, nil is no allowance
+	Allowance *Assets
+}
+
+func NewOffLedgerRequest(chainID ChainID, contract Hname, entryPoint Hname, params Dict, nonce uint64, gasBudget uint64, allowance *Assets) *OffLedgerRequest {
+	return &OffLedgerRequest{
+		ChainID:    chainID,
+		Contract:   contract,
+		EntryPoint: entryPoint,
+		Params:     params,
+		Nonce:      nonce,
+		GasBudget:  gasBudget,
+		Allowance:  allowance,
+	}
+}
+
+// EssenceBytes returns the serialization without signature, which is the message to sign
+func (r *OffLedgerRequest) EssenceBytes() []byte {
+	w := new(writer)
+	r.writeEssence(w)
+
+	return w.Bytes()
+}
+
+func (r *OffLedgerRequest) writeEssence(w *writer) {
+	w.writeByte(requestKindOffLedgerISC)
+	w.writeN(r.ChainID[:])
+	w.writeUint32(uint32(r.Contract))
+	w.writeUint32(uint32(r.EntryPoint))
+	r.Params.write(w)
+	w.writeAmount64(r.Nonce)
+	w.writeGas64(r.GasBudget)
+	r.Allowance.write(w)
+}
+
+// Sign signs the request with the key of the BIP44 chain, which never leaves the secret manager (e.g. Stronghold or Ledger)
+func (r *OffLedgerRequest) Sign(signer Ed25519Signer, bip44Chain types.Bip44Chain) (*SignedOffLedgerRequest, error) {
+	signature, err := signer.SignTransactionEssence(types.NewHexEncodedString(r.EssenceBytes()), bip44Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := types.HexEncodedString(signature.PublicKey).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	signatureBytes, err := types.HexEncodedString(signature.Signature).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.WithSignature(publicKey, signatureBytes)
+}
+
+// WithSignature attaches a signature created elsewhere, it's verified against the request
+func (r *OffLedgerRequest) WithSignature(publicKey []byte, signature []byte) (*SignedOffLedgerRequest, error) {
+	if len(publicKey) != ed25519.PublicKeySize || len(signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: unexpected key or signature size", ErrInvalidSignature)
+	}
+
+	if !ed25519.Verify(publicKey, r.EssenceBytes(), signature) {
+		return nil, fmt.Errorf("%w: signature does not match the request", ErrInvalidSignature)
+	}
+
+	return &SignedOffLedgerRequest{
+		OffLedgerRequest: *r,
+		publicKey:        append([]byte(nil), publicKey...),
+		signature:        append([]byte(nil), signature...),
+	}, nil
+}
+
+// SignedOffLedgerRequest is an off-ledger request ready to be posted to a node
+type SignedOffLedgerRequest struct {
+	OffLedgerRequest
+
+	publicKey []byte
+	signature []byte
+}
+
+// OffLedgerRequestFromBytes decodes and verifies a signed off-ledger request
+func OffLedgerRequestFromBytes(data []byte) (*SignedOffLedgerRequest, error) {
+	rd := newReader(data)
+
+	if kind := rd.readByte(); rd.err == nil && kind != requestKindOffLedgerISC {
+		return nil, fmt.Errorf("%w: unexpected request kind %d", ErrInvalidRequest, kind)
+	}
+
+	request := new(OffLedgerRequest)
+	copy(request.ChainID[:], rd.readN(ChainIDLength))
+	request.Contract = Hname(rd.readUint32())
+	request.EntryPoint = Hname(rd.readUint32())
+	request.Params = readDict(rd)
+	request.Nonce = rd.readUleb128()
+	request.GasBudget = rd.readGas64()
+	request.Allowance = readAssets(rd)
+	publicKey := rd.readN(ed25519.PublicKeySize)
+	signature := rd.readBytes()
+
+	if err := rd.done(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	if request.Allowance.IsEmpty() {
+		request.Allowance = nil
+	}
+
+	return request.WithSignature(publicKey, signature)
+}
+
+// Bytes returns the wire format of the request, as posted to a node
+func (s *SignedOffLedgerRequest) Bytes() []byte {
+	w := new(writer)
+	s.writeEssence(w)
+	w.writeN(s.publicKey)
+	w.writeBytes(s.signature)
+
+	return w.Bytes()
+}
+
+// ID is the hash of the signed request, followed by the output index 0
+func (s *SignedOffLedgerRequest) ID() RequestID {
+	var id RequestID
+
+	hash := blake2b.Sum256(s.Bytes())
+	copy(id[:], hash[:])
+
+	return id
+}
+
+func (s *SignedOffLedgerRequest) PublicKey() []byte {
+	return append([]byte(nil), s.publicKey...)
+}
+
+func (s *SignedOffLedgerRequest) Signature() []byte {
+	return append([]byte(nil), s.signature...)
+}
+
+// SenderAddress returns the Ed25519 address of the signer, which owns the L2 account the request is charged to
+func (s *SignedOffLedgerRequest) SenderAddress() types.Address {
+	pubKeyHash := blake2b.Sum256(s.publicKey)
+
+	return types.Address{
+		Type:       types.AddressTypeEd25519,
+		PubKeyHash: types.NewHexEncodedString(pubKeyHash[:]),
+	}
+}
@@ -0,0 +1,169 @@
+package isc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const (
+	// GasBudgetUnlimited lets the chain charge up to its maximum gas per request
+	GasBudgetUnlimited uint64 = math.MaxUint64
+
+	// DefaultGasBudget is enough for calls to the core contracts
+	DefaultGasBudget uint64 = 1_000_000
+)
+
+// Parameter names of the core contracts
+const (
+	ParamAgentID = "a"
+)
+
+// contractIdentityKindEmpty marks requests not sent by a contract, which are all requests built by this SDK
+const contractIdentityKindEmpty byte = 0
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+// RequestMetadata is stored in the metadata feature of an L1 output and tells the chain what to call
+type RequestMetadata struct {
+	TargetContract Hname
+	EntryPoint     Hname
+	Params         Dict
+
+	// Allowance the target contract may take from the sender's L2 account, nil is no allowance
+	Allowance *Assets
+	GasBudget uint64
+}
+
+// Bytes serializes the metadata in the format of Wasp v1
+func (m *RequestMetadata) Bytes() []byte {
+	w := new(writer)
+	w.writeByte(contractIdentityKindEmpty)
+	w.writeUint32(uint32(m.TargetContract))
+	w.writeUint32(uint32(m.EntryPoint))
+	w.writeGas64(m.GasBudget)
+	m.Params.write(w)
+	m.Allowance.write(w)
+
+	return w.Bytes()
+}
+
+// RequestMetadataFromBytes decodes request metadata sent by a non-contract sender
+func RequestMetadataFromBytes(data []byte) (*RequestMetadata, error) {
+	r := newReader(data)
+
+	if senderKind := r.readByte(); r.err == nil && senderKind != contractIdentityKindEmpty {
+		return nil, fmt.Errorf("%w: requests sent by contracts are not supported", ErrInvalidRequest)
+	}
+
+	metadata := &RequestMetadata{
+		TargetContract: Hname(r.readUint32()),
+		EntryPoint:     Hname(r.readUint32()),
+		GasBudget:      r.readGas64(),
+		Params:         readDict(r),
+		Allowance:      readAssets(r),
+	}
+
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	if metadata.Allowance.IsEmpty() {
+		metadata.Allowance = nil
+	}
+
+	return metadata, nil
+}
+
+// Request is an on-ledger request to a chain, sent as an L1 basic output to the chain's alias address
+type Request struct {
+	ChainID ChainID
+
+	Contract   Hname
+	EntryPoint Hname
+	Params     Dict
+
+	// Allowance the target contract may take from the sender's L2 account, nil is no allowance
+	Allowance *Assets
+	GasBudget uint64
+
+	// BaseTokens sent with the request. They include the storage deposit of the output and are credited
+	// to the sender's L2 account, minus the gas fee.
+	BaseTokens uint64
+
+	// NativeTokens sent with the request, credited to the sender's L2 account
+	NativeTokens []types.INativeToken
+}
+
+func (r *Request) Metadata() *RequestMetadata {
+	return &RequestMetadata{
+		TargetContract: r.Contract,
+		EntryPoint:     r.EntryPoint,
+		Params:         r.Params,
+		Allowance:      r.Allowance,
+		GasBudget:      r.GasBudget,
+	}
+}
+
+// Output builds the basic output carrying the request. Chains only accept requests with a sender feature,
+// so sender has to be an address of the sending account.
+func (r *Request) Output(sender types.Address) (types.OutputDataOutput, error) {
+	if r.BaseTokens == 0 {
+		return nil, fmt.Errorf("%w: base tokens are needed for the storage deposit", ErrInvalidRequest)
+	}
+
+	if r.ChainID == (ChainID{}) {
+		return nil, fmt.Errorf("%w: chain ID is empty", ErrInvalidRequest)
+	}
+
+	output := types.OutputDataOutput{
+		"type":   types.OutputTypeBasic,
+		"amount": fmt.Sprint(r.BaseTokens),
+		"unlockConditions": []types.UnlockCondition{
+			{Type: types.UnlockConditionTypeAddress, Address: addressPtr(r.ChainID.Address())},
+		},
+		"features": []types.Feature{
+			{Type: types.FeatureTypeSender, Address: &sender},
+			{Type: types.FeatureTypeMetadata, Data: types.NewHexEncodedString(r.Metadata().Bytes())},
+		},
+	}
+
+	if len(r.NativeTokens) > 0 {
+		output["nativeTokens"] = r.NativeTokens
+	}
+
+	return output, nil
+}
+
+// NewDepositRequest credits baseTokens to the sender's L2 account on the chain
+func NewDepositRequest(chainID ChainID, baseTokens uint64) *Request {
+	return &Request{
+		ChainID:    chainID,
+		Contract:   HnameAccounts,
+		EntryPoint: FuncDeposit,
+		GasBudget:  DefaultGasBudget,
+		BaseTokens: baseTokens,
+	}
+}
+
+// NewTransferAllowanceToRequest deposits baseTokens to the sender's L2 account and moves the allowance from it to
+// the target agent, e.g. an EVM account. The allowance has to leave enough base tokens to pay for gas.
+func NewTransferAllowanceToRequest(chainID ChainID, target AgentID, allowance *Assets, baseTokens uint64) *Request {
+	return &Request{
+		ChainID:    chainID,
+		Contract:   HnameAccounts,
+		EntryPoint: FuncTransferAllowanceTo,
+		Params: Dict{
+			ParamAgentID: target.Bytes(),
+		},
+		Allowance:  allowance,
+		GasBudget:  DefaultGasBudget,
+		BaseTokens: baseTokens,
+	}
+}
+
+func addressPtr(address types.Address) *types.Address {
+	return &address
+}
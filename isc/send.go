@@ -0,0 +1,32 @@
+package isc
+
+import (
+	"errors"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// SendRequest sends the request from the first public address of the account, which becomes the sender on L2
+func SendRequest(account *wasp_wallet_sdk.Account, request *Request, options *types.TransactionOptions) (*types.Transaction, error) {
+	details, err := account.Details()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(details.PublicAddresses) == 0 {
+		return nil, errors.New("account has no public address")
+	}
+
+	_, sender, err := AddressFromBech32(details.PublicAddresses[0].Address)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := request.Output(sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.SendOutputs([]types.OutputDataOutput{output}, options)
+}
@@ -0,0 +1,290 @@
+package isc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// maxErrorBodySize limits how much of an error response is read into WaspAPIError
+const maxErrorBodySize = 4096
+
+var ErrRequestFailed = errors.New("request failed on chain")
+
+// EVMRPCError is an error returned by the EVM JSON-RPC endpoint of a chain
+type EVMRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *EVMRPCError) Error() string {
+	return fmt.Sprintf("EVM JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// WaspAPIError is returned if the Wasp API responds with an unexpected status
+type WaspAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *WaspAPIError) Error() string {
+	return fmt.Sprintf("wasp API responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Receipt is the result of a processed request, as returned by the Wasp API
+type Receipt struct {
+	BlockIndex    uint32 `json:"blockIndex"`
+	RequestIndex  uint16 `json:"requestIndex"`
+	GasBudget     string `json:"gasBudget"`
+	GasBurned     string `json:"gasBurned"`
+	GasFeeCharged string `json:"gasFeeCharged"`
+
+	// ErrorMessage is set if the request failed
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// WaspClient talks to the REST API of a Wasp node, e.g. "http://localhost:9090".
+// Chain and agent IDs are sent bech32 encoded with the HRP of the L1 network the chain is anchored on.
+type WaspClient struct {
+	baseURL    string
+	hrp        string
+	httpClient *http.Client
+}
+
+// NewWaspClient creates a client for the Wasp API, a nil httpClient uses http.DefaultClient
+func NewWaspClient(baseURL string, hrp string, httpClient *http.Client) *WaspClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &WaspClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		hrp:        hrp,
+		httpClient: httpClient,
+	}
+}
+
+func (c *WaspClient) Hrp() string {
+	return c.hrp
+}
+
+// AccountNonce returns the nonce the next off-ledger request of the agent has to use
+func (c *WaspClient) AccountNonce(ctx context.Context, chainID ChainID, agentID AgentID) (uint64, error) {
+	var response struct {
+		Nonce string `json:"nonce"`
+	}
+
+	path := fmt.Sprintf("/v1/chains/%s/core/accounts/account/%s/nonce", chainID.Bech32(c.hrp), url.PathEscape(agentID.Bech32(c.hrp)))
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, &response); err != nil {
+		return 0, err
+	}
+
+	nonce, err := strconv.ParseUint(response.Nonce, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid nonce %q: %w", response.Nonce, err)
+	}
+
+	return nonce, nil
+}
+
+// PostOffLedgerRequest submits the request to the node, it is processed asynchronously
+func (c *WaspClient) PostOffLedgerRequest(ctx context.Context, request *SignedOffLedgerRequest) error {
+	body := struct {
+		ChainID string `json:"chainId"`
+		Request string `json:"request"`
+	}{
+		ChainID: request.ChainID.Bech32(c.hrp),
+		Request: string(types.NewHexEncodedString(request.Bytes())),
+	}
+
+	return c.do(ctx, http.MethodPost, "/v1/requests/offledger", body, http.StatusAccepted, nil)
+}
+
+// WaitForReceipt blocks until the request got processed by the chain, or the node gives up after timeout.
+// It returns ErrRequestFailed along with the receipt if the request failed.
+func (c *WaspClient) WaitForReceipt(ctx context.Context, chainID ChainID, requestID RequestID, timeout time.Duration) (*Receipt, error) {
+	path := fmt.Sprintf("/v1/chains/%s/requests/%s/wait?timeoutSeconds=%d", chainID.Bech32(c.hrp), requestID, int(timeout.Round(time.Second)/time.Second))
+
+	receipt := new(Receipt)
+	if err := c.do(ctx, http.MethodGet, path, nil, http.StatusOK, receipt); err != nil {
+		return nil, err
+	}
+
+	if receipt.ErrorMessage != "" {
+		return receipt, fmt.Errorf("%w: request %s: %s", ErrRequestFailed, requestID, receipt.ErrorMessage)
+	}
+
+	return receipt, nil
+}
+
+// EVMChainID returns the chain ID of the EVM of the chain, which EVM transactions are signed for
+func (c *WaspClient) EVMChainID(ctx context.Context, chainID ChainID) (uint64, error) {
+	var chainIDHex string
+	if err := c.callEVM(ctx, chainID, "eth_chainId", &chainIDHex); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(chainIDHex)
+}
+
+// EVMNonce returns the nonce the next EVM transaction of the address has to use
+func (c *WaspClient) EVMNonce(ctx context.Context, chainID ChainID, address EthereumAddress) (uint64, error) {
+	var nonceHex string
+	if err := c.callEVM(ctx, chainID, "eth_getTransactionCount", &nonceHex, address.String(), "pending"); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(nonceHex)
+}
+
+// EVMGasPrice returns the gas price of the chain, in wei
+func (c *WaspClient) EVMGasPrice(ctx context.Context, chainID ChainID) (*big.Int, error) {
+	var gasPriceHex string
+	if err := c.callEVM(ctx, chainID, "eth_gasPrice", &gasPriceHex); err != nil {
+		return nil, err
+	}
+
+	gasPrice, ok := new(big.Int).SetString(strings.TrimPrefix(gasPriceHex, "0x"), 16)
+	if !ok || !strings.HasPrefix(gasPriceHex, "0x") {
+		return nil, fmt.Errorf("invalid gas price %q", gasPriceHex)
+	}
+
+	return gasPrice, nil
+}
+
+// EstimateEVMGas returns the gas the transaction of the sender needs, the gas limit and nonce of the transaction are ignored
+func (c *WaspClient) EstimateEVMGas(ctx context.Context, chainID ChainID, from EthereumAddress, transaction *EVMTransaction) (uint64, error) {
+	call := map[string]string{
+		"from": from.String(),
+		"to":   transaction.To.String(),
+		"data": string(types.NewHexEncodedString(transaction.Data)),
+	}
+	if transaction.Value != nil {
+		call["value"] = "0x" + transaction.Value.Text(16)
+	}
+
+	var gasHex string
+	if err := c.callEVM(ctx, chainID, "eth_estimateGas", &gasHex, call); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(gasHex)
+}
+
+// SendEVMTransaction submits the transaction to the chain with eth_sendRawTransaction, it is processed asynchronously
+func (c *WaspClient) SendEVMTransaction(ctx context.Context, chainID ChainID, transaction *SignedEVMTransaction) error {
+	var hash string
+	if err := c.callEVM(ctx, chainID, "eth_sendRawTransaction", &hash, string(types.NewHexEncodedString(transaction.Bytes()))); err != nil {
+		return err
+	}
+
+	expected := transaction.Hash()
+	if hash != string(types.NewHexEncodedString(expected[:])) {
+		return fmt.Errorf("node returned transaction hash %s, expected %s", hash, types.NewHexEncodedString(expected[:]))
+	}
+
+	return nil
+}
+
+// callEVM calls a method of the EVM JSON-RPC endpoint of the chain
+func (c *WaspClient) callEVM(ctx context.Context, chainID ChainID, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	request := struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      int    `json:"id"`
+		Method  string `json:"method"`
+		Params  []any  `json:"params"`
+	}{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *EVMRPCError    `json:"error"`
+	}
+
+	path := fmt.Sprintf("/v1/chains/%s/evm", chainID.Bech32(c.hrp))
+	if err := c.do(ctx, http.MethodPost, path, request, http.StatusOK, &response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("invalid result of %s: %w", method, err)
+	}
+
+	return nil
+}
+
+// parseQuantity parses a 0x prefixed hex encoded integer of the EVM JSON-RPC API
+func parseQuantity(quantity string) (uint64, error) {
+	digits, ok := strings.CutPrefix(quantity, "0x")
+	if !ok {
+		return 0, fmt.Errorf("invalid quantity %q", quantity)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q: %w", quantity, err)
+	}
+
+	return value, nil
+}
+
+func (c *WaspClient) do(ctx context.Context, method string, path string, body any, expectedStatus int, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != expectedStatus {
+		data, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return &WaspAPIError{StatusCode: response.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
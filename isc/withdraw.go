@@ -0,0 +1,364 @@
+package isc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// DefaultReceiptTimeout is how long the node is asked to wait for a withdrawal to be processed if no timeout is given
+const DefaultReceiptTimeout = 30 * time.Second
+
+// ErrForeignAddress is returned by Withdraw for addresses that don't belong to the account, it would wait for their outputs forever
+var ErrForeignAddress = errors.New("address doesn't belong to the account")
+
+// Signer signs withdrawals of L1 addresses and of EVM accounts, as Wallet and SecretManager do
+type Signer interface {
+	Ed25519Signer
+	Secp256k1Signer
+}
+
+// Account is the L1 account Withdraw sends the assets to, as implemented by wasp_wallet_sdk.Account
+type Account interface {
+	Details() (*types.AccountDetails, error)
+	Sync(ctx context.Context, options *types.SyncOptions) (*types.Balance, error)
+	UnspentOutputs(filter *types.FilterOptions) ([]types.OutputData, error)
+}
+
+var (
+	_ Signer  = (*wasp_wallet_sdk.Wallet)(nil)
+	_ Signer  = (*wasp_wallet_sdk.SecretManager)(nil)
+	_ Account = (*wasp_wallet_sdk.Account)(nil)
+)
+
+// WithdrawParams describes a withdrawal from an L2 account to an L1 address
+type WithdrawParams struct {
+	ChainID ChainID
+
+	// Assets to withdraw, they have to cover the storage deposit of the L1 output
+	Assets *Assets
+
+	// From is the L2 account to withdraw from, an L1 address or an EVM account.
+	// Withdraw defaults to the first address of the account, SendWithdrawRequest requires it.
+	From AgentID
+
+	// To is the L1 address withdrawals of EVM accounts are sent to, Withdraw defaults to the first address of the account.
+	// Withdrawals of L1 addresses always go to the address itself.
+	To *types.Address
+
+	// GasBudget of the request, zero uses DefaultGasBudget. For EVM accounts it's the gas limit, zero estimates it.
+	GasBudget uint64
+
+	// ReceiptTimeout is how long to wait for the chain to process the request, zero uses DefaultReceiptTimeout
+	ReceiptTimeout time.Duration
+
+	// PollInterval of the L1 account sync while waiting for the withdrawn assets, zero uses wasp_wallet_sdk.DefaultInclusionPollInterval
+	PollInterval time.Duration
+}
+
+// WithdrawRequest is a posted withdrawal, a *SignedOffLedgerRequest or a *SignedEVMTransaction
+type WithdrawRequest interface {
+	ID() RequestID
+}
+
+// Withdrawal is the result of a completed withdrawal
+type Withdrawal struct {
+	Request WithdrawRequest
+	Receipt *Receipt
+
+	// Outputs sent by the chain to the L1 address
+	Outputs []types.OutputData
+}
+
+// NewWithdrawRequest builds the accounts.withdraw request, the assets to withdraw are its allowance
+func NewWithdrawRequest(chainID ChainID, assets *Assets, nonce uint64, gasBudget uint64) *OffLedgerRequest {
+	return NewOffLedgerRequest(chainID, HnameAccounts, FuncWithdraw, nil, nonce, gasBudget, assets)
+}
+
+// SendWithdrawRequest signs the withdrawal with the key of the BIP44 chain and posts it to the node, using the next nonce of params.From.
+// L1 addresses are withdrawn from with an accounts.withdraw off-ledger request signed with the Ed25519 key,
+// EVM accounts with a call of the ISC magic contract signed with the secp256k1 key, so the chain has to be of coin type CoinTypeEther.
+// It doesn't wait for the request to be processed.
+func SendWithdrawRequest(ctx context.Context, client *WaspClient, signer Signer, bip44Chain types.Bip44Chain, params WithdrawParams) (WithdrawRequest, error) {
+	if params.Assets.IsEmpty() {
+		return nil, errors.New("no assets to withdraw")
+	}
+
+	switch from := params.From.(type) {
+	case *AddressAgentID:
+		return sendWithdrawOffLedgerRequest(ctx, client, signer, bip44Chain, from, params)
+	case *EthereumAddressAgentID:
+		return sendWithdrawEVMTransaction(ctx, client, signer, bip44Chain, from, params)
+	case nil:
+		return nil, errors.New("account to withdraw from is required")
+	default:
+		return nil, fmt.Errorf("can't withdraw from agent ID of kind %d", from.Kind())
+	}
+}
+
+func sendWithdrawOffLedgerRequest(ctx context.Context, client *WaspClient, signer Ed25519Signer, bip44Chain types.Bip44Chain, from *AddressAgentID, params WithdrawParams) (*SignedOffLedgerRequest, error) {
+	gasBudget := params.GasBudget
+	if gasBudget == 0 {
+		gasBudget = DefaultGasBudget
+	}
+
+	nonce, err := client.AccountNonce(ctx, params.ChainID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	request, err := NewWithdrawRequest(params.ChainID, params.Assets, nonce, gasBudget).Sign(signer, bip44Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signing with the wrong BIP44 chain would charge a different account, with a nonce that doesn't match
+	if !AgentIDsEqual(from, NewAddressAgentID(request.SenderAddress())) {
+		return nil, fmt.Errorf("BIP44 chain doesn't belong to %s", from.Bech32(client.Hrp()))
+	}
+
+	if err := client.PostOffLedgerRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func sendWithdrawEVMTransaction(ctx context.Context, client *WaspClient, signer Secp256k1Signer, bip44Chain types.Bip44Chain, from *EthereumAddressAgentID, params WithdrawParams) (*SignedEVMTransaction, error) {
+	if from.ChainID() != params.ChainID {
+		return nil, fmt.Errorf("EVM account %s is not on chain %s", from.Bech32(client.Hrp()), params.ChainID.Bech32(client.Hrp()))
+	}
+
+	if params.To == nil {
+		return nil, errors.New("L1 address to withdraw to is required for EVM accounts")
+	}
+
+	data, err := NewMagicSendCallData(*params.To, params.Assets)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &EVMTransaction{
+		To:   MagicContractAddress,
+		Data: data,
+		Gas:  params.GasBudget,
+	}
+
+	if transaction.EVMChainID, err = client.EVMChainID(ctx, params.ChainID); err != nil {
+		return nil, fmt.Errorf("failed to get EVM chain ID: %w", err)
+	}
+
+	if transaction.Nonce, err = client.EVMNonce(ctx, params.ChainID, from.EthereumAddress()); err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	if transaction.GasPrice, err = client.EVMGasPrice(ctx, params.ChainID); err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	if transaction.Gas == 0 {
+		if transaction.Gas, err = client.EstimateEVMGas(ctx, params.ChainID, from.EthereumAddress(), transaction); err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+
+	signed, err := transaction.Sign(signer, bip44Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signing with the wrong BIP44 chain would charge a different account, with a nonce that doesn't match
+	if signed.Sender() != from.EthereumAddress() {
+		return nil, fmt.Errorf("BIP44 chain doesn't belong to %s", from.Bech32(client.Hrp()))
+	}
+
+	if err := client.SendEVMTransaction(ctx, params.ChainID, signed); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// Withdraw withdraws assets from an L2 account back to the first address of the account, by default from the L2 account of that address.
+// An L1 address to withdraw from, and the To address of an EVM account, have to belong to the account, otherwise it fails with ErrForeignAddress.
+// EVM accounts are signed with the key of the first EVM address of the account, address index 0 of coin type CoinTypeEther.
+// It waits for the chain to process the request and for the withdrawn outputs to show up in the account.
+func Withdraw(ctx context.Context, account Account, signer Signer, client *WaspClient, params WithdrawParams) (*Withdrawal, error) {
+	details, err := account.Details()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(details.PublicAddresses) == 0 {
+		return nil, errors.New("account has no public address")
+	}
+
+	accountAddress := details.PublicAddresses[0]
+	_, address, err := AddressFromBech32(accountAddress.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.From == nil {
+		params.From = NewAddressAgentID(address)
+	}
+
+	// The withdrawn assets go to the address withdrawn from, its key signs the request
+	if from, ok := params.From.(*AddressAgentID); ok {
+		owned, err := findAccountAddress(details, from.Address())
+		if err != nil {
+			return nil, err
+		}
+		if owned == nil {
+			return nil, fmt.Errorf("%w: %s", ErrForeignAddress, from.Bech32(client.Hrp()))
+		}
+
+		accountAddress = *owned
+		address = from.Address()
+	}
+
+	if params.To == nil {
+		params.To = &address
+	}
+
+	// Only withdrawals of EVM accounts go to To, where Withdraw waits for the outputs
+	if params.From.Kind() == AgentIDKindEthereumAddress {
+		owned, err := findAccountAddress(details, *params.To)
+		if err != nil {
+			return nil, err
+		}
+		if owned == nil {
+			to, _ := AddressToBech32(client.Hrp(), *params.To)
+			return nil, fmt.Errorf("%w: %s", ErrForeignAddress, to)
+		}
+	}
+
+	if params.ReceiptTimeout <= 0 {
+		params.ReceiptTimeout = DefaultReceiptTimeout
+	}
+
+	// Outputs the chain sent before are not part of this withdrawal
+	if _, err := account.Sync(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	knownOutputs, err := chainOutputs(account, params.ChainID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bip44Chain := types.NewBip44Chain(details.CoinType, details.Index, accountAddress.KeyIndex, accountAddress.Internal)
+	if params.From.Kind() == AgentIDKindEthereumAddress {
+		bip44Chain = types.NewBip44Chain(types.CoinTypeEther, details.Index, 0, false)
+	}
+
+	request, err := SendWithdrawRequest(ctx, client, signer, bip44Chain, params)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := client.WaitForReceipt(ctx, params.ChainID, request.ID(), params.ReceiptTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := waitForChainOutputs(ctx, account, params.ChainID, knownOutputs, params.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Withdrawal{
+		Request: request,
+		Receipt: receipt,
+		Outputs: outputs,
+	}, nil
+}
+
+// findAccountAddress returns the public or internal address of the account, nil if it's not one of them
+func findAccountAddress(details *types.AccountDetails, address types.Address) (*types.AccountAddress, error) {
+	for _, addresses := range [][]types.AccountAddress{details.PublicAddresses, details.InternalAddresses} {
+		for i := range addresses {
+			_, accountAddress, err := AddressFromBech32(addresses[i].Address)
+			if err != nil {
+				return nil, err
+			}
+
+			if AgentIDsEqual(NewAddressAgentID(accountAddress), NewAddressAgentID(address)) {
+				return &addresses[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// waitForChainOutputs syncs the account until it owns outputs sent by the chain which are not known yet
+func waitForChainOutputs(ctx context.Context, account Account, chainID ChainID, knownOutputs []types.OutputData, pollInterval time.Duration) ([]types.OutputData, error) {
+	if pollInterval <= 0 {
+		pollInterval = wasp_wallet_sdk.DefaultInclusionPollInterval
+	}
+
+	known := make(map[types.OutputId]struct{}, len(knownOutputs))
+	for _, output := range knownOutputs {
+		known[output.OutputId] = struct{}{}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := account.Sync(ctx, nil); err != nil {
+			return nil, err
+		}
+
+		outputs, err := chainOutputs(account, chainID, known)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(outputs) > 0 {
+			return outputs, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// chainOutputs returns the unspent outputs of the account with the chain as sender, except the known ones
+func chainOutputs(account Account, chainID ChainID, known map[types.OutputId]struct{}) ([]types.OutputData, error) {
+	unspentOutputs, err := account.UnspentOutputs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []types.OutputData
+	for _, outputData := range unspentOutputs {
+		if _, ok := known[outputData.OutputId]; ok {
+			continue
+		}
+
+		output, err := types.ParseOutput(outputData.Output)
+		if err != nil {
+			return nil, err
+		}
+
+		sender := output.Feature(types.FeatureTypeSender)
+		if sender == nil || sender.Address == nil {
+			continue
+		}
+
+		if senderChainID, err := ChainIDFromAddress(*sender.Address); err == nil && senderChainID == chainID {
+			outputs = append(outputs, outputData)
+		}
+	}
+
+	return outputs, nil
+}
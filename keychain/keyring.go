@@ -0,0 +1,63 @@
+package keychain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/awnumar/memguard"
+	"github.com/zalando/go-keyring"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+)
+
+const (
+	DefaultKeyringService = "wasp-wallet-sdk"
+	DefaultKeyringUser    = "mnemonic"
+)
+
+var ErrMnemonicNotInKeyring = errors.New("no mnemonic stored in the OS keyring")
+
+// StoreMnemonicInKeyring stores the mnemonic in the keyring of the OS, replacing a stored one
+func StoreMnemonicInKeyring(service string, user string, mnemonic *memguard.Enclave) error {
+	buffer, err := mnemonic.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	// buffer.String aliases the protected memory, which is destroyed before the keyring might be done with it
+	return keyring.Set(service, user, string(buffer.Bytes()))
+}
+
+// LoadMnemonicFromKeyring reads the mnemonic from the keyring of the OS.
+// The keyring API returns a string, so a copy of the mnemonic remains in unprotected memory until it's collected.
+func LoadMnemonicFromKeyring(service string, user string) (*memguard.Enclave, error) {
+	mnemonic, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("%w: service %q, user %q", ErrMnemonicNotInKeyring, service, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return memguard.NewEnclave([]byte(mnemonic)), nil
+}
+
+func DeleteMnemonicFromKeyring(service string, user string) error {
+	err := keyring.Delete(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("%w: service %q, user %q", ErrMnemonicNotInKeyring, service, user)
+	}
+
+	return err
+}
+
+// NewKeyringProvider uses the mnemonic stored in the keyring of the OS, see StoreMnemonicInKeyring
+func NewKeyringProvider(sdk *wasp_wallet_sdk.IOTASDK, service string, user string, options Options) (*SecretManagerProvider, error) {
+	mnemonic, err := LoadMnemonicFromKeyring(service, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMnemonicProvider(sdk, mnemonic, options)
+}
@@ -0,0 +1,156 @@
+// Package keychain gives Wasp tools a single abstraction over the places their seed can live in.
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/awnumar/memguard"
+	"golang.org/x/crypto/blake2b"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+var ErrSecretRequired = errors.New("secret required")
+
+// publicKeyMessage is signed to get the public key of an address, the native secret managers only expose it with a signature.
+// It's the hash of a fixed string, so it can't be mistaken for a transaction essence.
+var publicKeyMessage = blake2b.Sum256([]byte("wasp-wallet-sdk keychain public key"))
+
+// Provider signs with the keys of a seed which never leaves the provider.
+// Keys are selected by their address index within the configured coin type and account.
+type Provider interface {
+	// Address returns the bech32 encoded Ed25519 address of the key
+	Address(addressIndex uint32) (string, error)
+
+	// Sign signs the message with the Ed25519 key
+	Sign(message []byte, addressIndex uint32) (*types.Ed25519Signature, error)
+
+	// SignEVM signs the keccak256 hash of the message with the secp256k1 key of the Ether coin type
+	SignEVM(message []byte, addressIndex uint32) (*types.Secp256k1EcdsaSignature, error)
+
+	// PublicKey returns the Ed25519 public key of the address
+	PublicKey(addressIndex uint32) ([]byte, error)
+
+	Close() error
+}
+
+// Options select the keys of a provider
+type Options struct {
+	CoinType     types.CoinType
+	AccountIndex uint32
+
+	// Bech32Hrp of the addresses, empty uses the HRP of the coin type
+	Bech32Hrp string
+}
+
+var _ Provider = (*SecretManagerProvider)(nil)
+
+// SecretManagerProvider is the Provider of all secret managers supported by the SDK
+type SecretManagerProvider struct {
+	secretManager *wasp_wallet_sdk.SecretManager
+	options       Options
+
+	// publicKeys caches the public keys by address index, so a Ledger asks to sign publicKeyMessage only once per address
+	publicKeys      map[uint32][]byte
+	publicKeysMutex sync.Mutex
+}
+
+// NewSecretManagerProvider wraps a secret manager, closing the provider closes the secret manager
+func NewSecretManagerProvider(secretManager *wasp_wallet_sdk.SecretManager, options Options) *SecretManagerProvider {
+	return &SecretManagerProvider{
+		secretManager: secretManager,
+		options:       options,
+		publicKeys:    make(map[uint32][]byte),
+	}
+}
+
+// NewMnemonicProvider keeps the mnemonic in memory, only the native secret manager holds it
+func NewMnemonicProvider(sdk *wasp_wallet_sdk.IOTASDK, mnemonic *memguard.Enclave, options Options) (*SecretManagerProvider, error) {
+	if mnemonic == nil {
+		return nil, fmt.Errorf("%w: mnemonic", ErrSecretRequired)
+	}
+
+	secretManager, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretManagerProvider(secretManager, options), nil
+}
+
+// NewStrongholdProvider opens the Stronghold snapshot, it's created if it doesn't exist
+func NewStrongholdProvider(sdk *wasp_wallet_sdk.IOTASDK, password *memguard.Enclave, snapshotPath string, options Options) (*SecretManagerProvider, error) {
+	if password == nil {
+		return nil, fmt.Errorf("%w: stronghold password", ErrSecretRequired)
+	}
+
+	secretManager, err := wasp_wallet_sdk.NewStrongholdSecretManager(sdk, password, snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretManagerProvider(secretManager, options), nil
+}
+
+// NewLedgerProvider uses a connected Ledger Nano, or the Speculos simulator if isEmulator is set
+func NewLedgerProvider(sdk *wasp_wallet_sdk.IOTASDK, isEmulator bool, options Options) (*SecretManagerProvider, error) {
+	secretManager, err := wasp_wallet_sdk.NewLedgerSecretManager(sdk, isEmulator)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretManagerProvider(secretManager, options), nil
+}
+
+// SecretManager gives access to the underlying secret manager, e.g. to sign ISC requests
+func (p *SecretManagerProvider) SecretManager() *wasp_wallet_sdk.SecretManager {
+	return p.secretManager
+}
+
+func (p *SecretManagerProvider) Address(addressIndex uint32) (string, error) {
+	return p.secretManager.GenerateEd25519Address(addressIndex, p.options.AccountIndex, p.options.Bech32Hrp, p.options.CoinType, nil)
+}
+
+func (p *SecretManagerProvider) Sign(message []byte, addressIndex uint32) (*types.Ed25519Signature, error) {
+	return p.secretManager.SignTransactionEssence(types.NewHexEncodedString(message), p.bip44Chain(p.options.CoinType, addressIndex))
+}
+
+func (p *SecretManagerProvider) SignEVM(message []byte, addressIndex uint32) (*types.Secp256k1EcdsaSignature, error) {
+	return p.secretManager.SignSecp256k1Ecdsa(types.NewHexEncodedString(message), p.bip44Chain(types.CoinTypeEther, addressIndex))
+}
+
+// PublicKey is taken from a signature of a fixed message, as the native secret managers don't expose public keys otherwise.
+// A Ledger asks to confirm the signature once per address index.
+func (p *SecretManagerProvider) PublicKey(addressIndex uint32) ([]byte, error) {
+	p.publicKeysMutex.Lock()
+	defer p.publicKeysMutex.Unlock()
+
+	if publicKey, ok := p.publicKeys[addressIndex]; ok {
+		return publicKey, nil
+	}
+
+	signature, err := p.Sign(publicKeyMessage[:], addressIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := types.HexEncodedString(signature.PublicKey).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	p.publicKeys[addressIndex] = publicKey
+
+	return publicKey, nil
+}
+
+func (p *SecretManagerProvider) Close() error {
+	return p.secretManager.Close()
+}
+
+func (p *SecretManagerProvider) bip44Chain(coinType types.CoinType, addressIndex uint32) types.Bip44Chain {
+	return types.NewBip44Chain(coinType, p.options.AccountIndex, addressIndex, false)
+}
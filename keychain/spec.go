@@ -0,0 +1,117 @@
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/awnumar/memguard"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+)
+
+type Kind string
+
+const (
+	KindMnemonic   Kind = "mnemonic"
+	KindStronghold Kind = "stronghold"
+	KindLedger     Kind = "ledger"
+	KindKeyring    Kind = "keyring"
+)
+
+// ledgerEmulatorArg selects the Speculos simulator instead of a device
+const ledgerEmulatorArg = "emulator"
+
+var ErrInvalidSpec = errors.New("invalid keychain spec")
+
+// Spec is a parsed provider config string, one of
+//
+//	mnemonic
+//	stronghold:<snapshot path>
+//	ledger
+//	ledger:emulator
+//	keyring
+//	keyring:<service>
+type Spec struct {
+	Kind Kind
+	Arg  string
+}
+
+// Secrets are the secrets a provider may need, only the one required by the selected kind has to be set
+type Secrets struct {
+	// Mnemonic of the mnemonic provider
+	Mnemonic *memguard.Enclave
+
+	// Password of the Stronghold snapshot
+	Password *memguard.Enclave
+}
+
+func ParseSpec(s string) (Spec, error) {
+	kind, arg, _ := strings.Cut(strings.TrimSpace(s), ":")
+	spec := Spec{Kind: Kind(strings.ToLower(kind)), Arg: arg}
+
+	switch spec.Kind {
+	case KindMnemonic:
+		if arg != "" {
+			return Spec{}, fmt.Errorf("%w: %s takes no argument", ErrInvalidSpec, kind)
+		}
+	case KindStronghold:
+		if arg == "" {
+			return Spec{}, fmt.Errorf("%w: stronghold requires a snapshot path", ErrInvalidSpec)
+		}
+	case KindLedger:
+		if arg != "" && arg != ledgerEmulatorArg {
+			return Spec{}, fmt.Errorf("%w: unknown ledger argument %q", ErrInvalidSpec, arg)
+		}
+	case KindKeyring:
+		if spec.Arg == "" {
+			spec.Arg = DefaultKeyringService
+		}
+	default:
+		return Spec{}, fmt.Errorf("%w: unknown kind %q", ErrInvalidSpec, kind)
+	}
+
+	return spec, nil
+}
+
+func (s Spec) String() string {
+	if s.Arg == "" {
+		return string(s.Kind)
+	}
+
+	return string(s.Kind) + ":" + s.Arg
+}
+
+// Open parses the config string and opens the selected provider
+func Open(sdk *wasp_wallet_sdk.IOTASDK, spec string, secrets Secrets, options Options) (Provider, error) {
+	parsed, err := ParseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Open(sdk, secrets, options)
+}
+
+func (s Spec) Open(sdk *wasp_wallet_sdk.IOTASDK, secrets Secrets, options Options) (Provider, error) {
+	switch s.Kind {
+	case KindMnemonic:
+		return asProvider(NewMnemonicProvider(sdk, secrets.Mnemonic, options))
+	case KindStronghold:
+		return asProvider(NewStrongholdProvider(sdk, secrets.Password, s.Arg, options))
+	case KindLedger:
+		return asProvider(NewLedgerProvider(sdk, s.Arg == ledgerEmulatorArg, options))
+	case KindKeyring:
+		return asProvider(NewKeyringProvider(sdk, s.Arg, DefaultKeyringUser, options))
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidSpec, s.Kind)
+	}
+}
+
+// asProvider avoids returning a typed nil Provider on errors
+func asProvider(provider *SecretManagerProvider, err error) (Provider, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
@@ -0,0 +1,68 @@
+package lib_loader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LibraryPathEnvVar can point to the native library file or to the directory containing it.
+const LibraryPathEnvVar = "IOTA_SDK_LIB"
+
+var ErrLibraryNotFound = errors.New("IOTA SDK native library not found")
+
+// FindLibrary searches the native library in the following order:
+//   - the path in IOTA_SDK_LIB (file or directory)
+//   - the directory of the executable, and its lib and ../lib subdirectories
+//   - the dynamic loader search path (LD_LIBRARY_PATH, DYLD_LIBRARY_PATH or PATH)
+//   - the standard library directories of the OS
+func FindLibrary() (string, error) {
+	candidates := SearchPaths()
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	if envPath := os.Getenv(LibraryPathEnvVar); envPath != "" {
+		return "", fmt.Errorf("%w: %s=%q does not contain %s", ErrLibraryNotFound, LibraryPathEnvVar, envPath, LibraryFileName)
+	}
+
+	return "", fmt.Errorf("%w: searched %s", ErrLibraryNotFound, strings.Join(candidates, ", "))
+}
+
+// SearchPaths returns all candidate file paths FindLibrary checks, in order.
+func SearchPaths() []string {
+	dirs := make([]string, 0)
+	candidates := make([]string, 0)
+
+	if envPath := os.Getenv(LibraryPathEnvVar); envPath != "" {
+		if info, err := os.Stat(envPath); err == nil && info.IsDir() {
+			dirs = append(dirs, envPath)
+		} else {
+			candidates = append(candidates, envPath)
+		}
+	}
+
+	if executable, err := os.Executable(); err == nil {
+		executableDir := filepath.Dir(executable)
+		dirs = append(dirs, executableDir, filepath.Join(executableDir, "lib"), filepath.Join(executableDir, "..", "lib"))
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv(searchPathEnvVar)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	dirs = append(dirs, standardLibraryDirs...)
+
+	for _, dir := range dirs {
+		candidates = append(candidates, filepath.Join(dir, LibraryFileName))
+	}
+
+	return candidates
+}
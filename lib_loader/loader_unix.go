@@ -11,3 +11,7 @@ func LoadLibrary(libPath string) (uintptr, error) {
 func UnloadLibrary(handle uintptr) error {
 	return purego.Dlclose(handle)
 }
+
+func LookupSymbol(handle uintptr, name string) (uintptr, error) {
+	return purego.Dlsym(handle, name)
+}
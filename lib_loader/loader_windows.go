@@ -12,3 +12,7 @@ func LoadLibrary(libPath string) (uintptr, error) {
 func UnloadLibrary(handle uintptr) error {
 	return windows.FreeLibrary(windows.Handle(handle))
 }
+
+func LookupSymbol(handle uintptr, name string) (uintptr, error) {
+	return windows.GetProcAddress(windows.Handle(handle), name)
+}
@@ -0,0 +1,13 @@
+//go:build darwin
+
+package lib_loader
+
+const LibraryFileName = "libiota_sdk.dylib"
+
+const searchPathEnvVar = "DYLD_LIBRARY_PATH"
+
+var standardLibraryDirs = []string{
+	"/usr/local/lib",
+	"/opt/homebrew/lib",
+	"/usr/lib",
+}
@@ -0,0 +1,15 @@
+//go:build linux
+
+package lib_loader
+
+const LibraryFileName = "libiota_sdk.so"
+
+const searchPathEnvVar = "LD_LIBRARY_PATH"
+
+var standardLibraryDirs = []string{
+	"/usr/local/lib",
+	"/usr/local/lib64",
+	"/usr/lib",
+	"/usr/lib64",
+	"/lib",
+}
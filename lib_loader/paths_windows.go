@@ -0,0 +1,10 @@
+//go:build windows
+
+package lib_loader
+
+// Rust names Windows libraries without the lib prefix
+const LibraryFileName = "iota_sdk.dll"
+
+const searchPathEnvVar = "PATH"
+
+var standardLibraryDirs = []string{}
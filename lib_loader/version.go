@@ -0,0 +1,56 @@
+package lib_loader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version of the native binding. Pre-release and build suffixes are ignored.
+type Version struct {
+	Major uint32
+	Minor uint32
+	Patch uint32
+}
+
+func ParseVersion(version string) (Version, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if end := strings.IndexAny(version, "-+"); end >= 0 {
+		version = version[:end]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q, expected major.minor.patch", version)
+	}
+
+	numbers := make([]uint32, 3)
+	for i, part := range parts {
+		number, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+		}
+
+		numbers[i] = uint32(number)
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 if v is lower, equal or greater than other.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]uint32{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] < pair[1] {
+			return -1
+		}
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
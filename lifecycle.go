@@ -0,0 +1,340 @@
+package wasp_wallet_sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+type HandleKind string
+
+const (
+	HandleKindClient        HandleKind = "client"
+	HandleKindWallet        HandleKind = "wallet"
+	HandleKindSecretManager HandleKind = "secretManager"
+)
+
+var ErrHandleClosed = errors.New("handle is already closed")
+
+var (
+	_ io.Closer = (*IOTASDK)(nil)
+	_ io.Closer = (*Wallet)(nil)
+	_ io.Closer = (*SecretManager)(nil)
+	_ io.Closer = (*Client)(nil)
+)
+
+// HandleInfo describes a live native handle tracked by the SDK
+type HandleInfo struct {
+	Kind HandleKind
+	Ptr  uintptr
+	// Refs is the amount of owners of the handle; the native handle is destroyed when the last one releases it
+	Refs int
+	// ParentKind and ParentPtr are set for handles derived from a wallet
+	ParentKind HandleKind
+	ParentPtr  uintptr
+	// CreationStack is only recorded if leak detection is enabled
+	CreationStack string
+}
+
+// LeakReport describes a handle that was garbage collected without being closed
+type LeakReport struct {
+	Kind          HandleKind
+	Ptr           uintptr
+	CreationStack string
+}
+
+type handleKey struct {
+	kind HandleKind
+	ptr  uintptr
+}
+
+type nativeHandle struct {
+	key           handleKey
+	seq           uint64
+	refs          int
+	closed        bool
+	parent        *nativeHandle
+	children      []*nativeHandle
+	creationStack string
+}
+
+type handleRegistry struct {
+	mu      sync.Mutex
+	handles map[handleKey]*nativeHandle
+	seq     uint64
+
+	leakDetection bool
+	leakReporter  func(LeakReport)
+}
+
+func newHandleRegistry() *handleRegistry {
+	return &handleRegistry{
+		handles: make(map[handleKey]*nativeHandle),
+	}
+}
+
+// SetLeakDetection enables reporting of Wallets, SecretManagers and Clients that are garbage collected without being closed.
+// Creation stack traces are recorded for every handle created while it's enabled, which is expensive; use it for debugging only.
+// A nil reporter prints the reports to stderr.
+func (i *IOTASDK) SetLeakDetection(enabled bool, reporter func(LeakReport)) {
+	if reporter == nil {
+		reporter = func(report LeakReport) {
+			fmt.Fprintf(os.Stderr, "wasp-wallet-sdk: %s handle 0x%x was garbage collected without being closed, created at:\n%s\n", report.Kind, report.Ptr, report.CreationStack)
+		}
+	}
+
+	i.handles.mu.Lock()
+	defer i.handles.mu.Unlock()
+
+	i.handles.leakDetection = enabled
+	i.handles.leakReporter = reporter
+}
+
+// LiveHandles returns all native handles that were not destroyed yet, in creation order
+func (i *IOTASDK) LiveHandles() []HandleInfo {
+	i.handles.mu.Lock()
+	defer i.handles.mu.Unlock()
+
+	handles := make([]*nativeHandle, 0, len(i.handles.handles))
+	for _, handle := range i.handles.handles {
+		handles = append(handles, handle)
+	}
+	sort.Slice(handles, func(a, b int) bool { return handles[a].seq < handles[b].seq })
+
+	infos := make([]HandleInfo, 0, len(handles))
+	for _, handle := range handles {
+		info := HandleInfo{
+			Kind:          handle.key.kind,
+			Ptr:           handle.key.ptr,
+			Refs:          handle.refs,
+			CreationStack: handle.creationStack,
+		}
+		if handle.parent != nil {
+			info.ParentKind = handle.parent.key.kind
+			info.ParentPtr = handle.parent.key.ptr
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// track registers a native handle with a single owner, parent handles close their children before themselves
+func (r *handleRegistry) track(kind HandleKind, ptr uintptr, parent *nativeHandle) *nativeHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := handleKey{kind: kind, ptr: ptr}
+	if handle, ok := r.handles[key]; ok {
+		return handle
+	}
+
+	r.seq++
+	handle := &nativeHandle{
+		key:  key,
+		seq:  r.seq,
+		refs: 1,
+	}
+
+	if r.leakDetection {
+		handle.creationStack = string(debug.Stack())
+	}
+
+	if parent != nil && !parent.closed {
+		handle.parent = parent
+		parent.children = append(parent.children, handle)
+	}
+
+	r.handles[key] = handle
+
+	return handle
+}
+
+func (r *handleRegistry) lookup(kind HandleKind, ptr uintptr) *nativeHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.handles[handleKey{kind: kind, ptr: ptr}]
+}
+
+// retain adds an owner to the handle
+func (r *handleRegistry) retain(handle *nativeHandle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if handle.closed {
+		return fmt.Errorf("%w: %s", ErrHandleClosed, handle.key.kind)
+	}
+
+	handle.refs++
+
+	return nil
+}
+
+// release removes an owner from the handle and returns the handles to destroy, children first.
+// force destroys the handle and its children regardless of their owners.
+func (r *handleRegistry) release(handle *nativeHandle, force bool) []*nativeHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	destroyOrder := make([]*nativeHandle, 0)
+	r.releaseLocked(handle, force, &destroyOrder)
+
+	return destroyOrder
+}
+
+func (r *handleRegistry) releaseLocked(handle *nativeHandle, force bool, destroyOrder *[]*nativeHandle) {
+	if handle.closed {
+		return
+	}
+
+	handle.refs--
+	if handle.refs > 0 && !force {
+		return
+	}
+
+	// Release the reference the handle holds on each of its children, newest first
+	for index := len(handle.children) - 1; index >= 0; index-- {
+		child := handle.children[index]
+		child.parent = nil
+		r.releaseLocked(child, force, destroyOrder)
+	}
+	handle.children = nil
+
+	if handle.parent != nil {
+		siblings := handle.parent.children
+		for index, sibling := range siblings {
+			if sibling == handle {
+				handle.parent.children = append(siblings[:index], siblings[index+1:]...)
+				break
+			}
+		}
+		handle.parent = nil
+	}
+
+	handle.closed = true
+	delete(r.handles, handle.key)
+	*destroyOrder = append(*destroyOrder, handle)
+}
+
+// roots returns all handles without a parent, newest first
+func (r *handleRegistry) roots() []*nativeHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roots := make([]*nativeHandle, 0)
+	for _, handle := range r.handles {
+		if handle.parent == nil {
+			roots = append(roots, handle)
+		}
+	}
+	sort.Slice(roots, func(a, b int) bool { return roots[a].seq > roots[b].seq })
+
+	return roots
+}
+
+func (r *handleRegistry) isClosed(handle *nativeHandle) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return handle.closed
+}
+
+// watchLeak reports obj if it gets garbage collected while its handle is still open
+func watchLeak[T any](r *handleRegistry, obj *T, handle *nativeHandle) {
+	r.mu.Lock()
+	enabled, reporter := r.leakDetection, r.leakReporter
+	r.mu.Unlock()
+
+	if !enabled {
+		return
+	}
+
+	runtime.SetFinalizer(obj, func(*T) {
+		if r.isClosed(handle) {
+			return
+		}
+
+		reporter(LeakReport{
+			Kind:          handle.key.kind,
+			Ptr:           handle.key.ptr,
+			CreationStack: handle.creationStack,
+		})
+	})
+}
+
+// releaseHandle releases one owner of a native handle and destroys it once no owner is left.
+// Untracked pointers are destroyed immediately.
+func (i *IOTASDK) releaseHandle(kind HandleKind, ptr uintptr) error {
+	if ptr == 0 {
+		return nil
+	}
+
+	handle := i.handles.lookup(kind, ptr)
+	if handle == nil {
+		return i.destroyNative(kind, ptr)
+	}
+
+	return i.releaseTrackedHandle(handle)
+}
+
+// acquireHandle retains the handle for the duration of a native call, so closing its owners during the call doesn't destroy it.
+// The returned release has to be called once the call returned, the handle is destroyed then if its owners were closed.
+func (i *IOTASDK) acquireHandle(kind HandleKind, handle *nativeHandle) (release func(), err error) {
+	if handle == nil {
+		return func() {}, fmt.Errorf("%w: %s", ErrHandleClosed, kind)
+	}
+
+	if err := i.handles.retain(handle); err != nil {
+		return func() {}, err
+	}
+
+	return func() {
+		_ = i.releaseTrackedHandle(handle)
+	}, nil
+}
+
+// releaseTrackedHandle releases one owner of the handle, closing an already closed handle is a no-op
+func (i *IOTASDK) releaseTrackedHandle(handle *nativeHandle) error {
+	return i.destroyHandles(i.handles.release(handle, false))
+}
+
+func (i *IOTASDK) destroyHandles(handles []*nativeHandle) error {
+	errs := make([]error, 0)
+	for _, handle := range handles {
+		if err := i.destroyNative(handle.key.kind, handle.key.ptr); err != nil {
+			errs = append(errs, fmt.Errorf("failed to destroy %s: %w", handle.key.kind, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (i *IOTASDK) destroyNative(kind HandleKind, ptr uintptr) error {
+	switch kind {
+	case HandleKindClient:
+		return i.destroyNativeClient(IotaClientPtr(ptr))
+	case HandleKindWallet:
+		return i.destroyNativeWallet(IotaWalletPtr(ptr))
+	case HandleKindSecretManager:
+		return i.destroyNativeSecretManager(IotaSecretManagerPtr(ptr))
+	default:
+		return fmt.Errorf("unknown handle kind %q", kind)
+	}
+}
+
+// closeAllHandles destroys every live handle, newest first and children before their parents
+func (i *IOTASDK) closeAllHandles() error {
+	errs := make([]error, 0)
+	for _, root := range i.handles.roots() {
+		errs = append(errs, i.destroyHandles(i.handles.release(root, true)))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,310 @@
+package wasp_wallet_sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// LevelTrace is the slog level of native trace records, below slog.LevelDebug
+const LevelTrace = slog.LevelDebug - 4
+
+// nativeLogPollInterval is how often the native log file is checked for new records
+const nativeLogPollInterval = 100 * time.Millisecond
+
+const redacted = "[REDACTED]"
+
+var (
+	// nativeLogLinePattern matches the records of the native logger, e.g. "[2024-03-01][12:00:00][iota_sdk::wallet][INFO] message"
+	nativeLogLinePattern = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2})\]\[(\d{2}:\d{2}:\d{2})\]\[([^\]]*)\]\[(TRACE|DEBUG|INFO|WARN|ERROR)\] ?(.*)$`)
+	ansiEscapePattern    = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	// hexKeyPattern matches hex encoded private keys and seeds after their name, e.g. "private key: 0x..." or "\"secretKey\":\"0x...\"".
+	// Ed25519 and secp256k1 private keys are 32 bytes, expanded Ed25519 keys and seeds 64 bytes.
+	// Transaction, block and output IDs of the same length are kept.
+	hexKeyPattern = regexp.MustCompile(`(?i)\b((?:private|secret)[ _]?key|(?:hex[ _]?)?seed)([\\"'\s:=]*)(?:0x)?[0-9a-f]{64}(?:[0-9a-f]{64})?\b`)
+
+	// mnemonicPattern matches 12 or more lowercase words with the length of BIP39 words
+	mnemonicPattern = regexp.MustCompile(`\b[a-z]{3,8}(?:\s+[a-z]{3,8}){11,}\b`)
+)
+
+var nativeLogLevels = map[string]slog.Level{
+	"TRACE": LevelTrace,
+	"DEBUG": slog.LevelDebug,
+	"INFO":  slog.LevelInfo,
+	"WARN":  slog.LevelWarn,
+	"ERROR": slog.LevelError,
+}
+
+// RedactSecrets replaces everything that looks like a mnemonic, and hex encoded private keys and seeds named as such
+func RedactSecrets(message string) string {
+	message = mnemonicPattern.ReplaceAllString(message, redacted)
+
+	return hexKeyPattern.ReplaceAllString(message, "${1}${2}"+redacted)
+}
+
+// NativeLogBridge forwards the records of a native log file to a slog logger
+type NativeLogBridge struct {
+	logger  *slog.Logger
+	file    *os.File
+	tempDir string
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	pending []byte
+	record  *slog.Record
+
+	// truncate empties the file once it was read, see truncateRead
+	truncate bool
+}
+
+// InitSlogLogger initializes the native logger to write into a temporary file, which is forwarded to the slog logger.
+// Name and ColorEnabled of the logger config are overridden. Records are redacted with RedactSecrets.
+// The raw records are only kept on disk until they were forwarded, the file is truncated after each read and removed on Close.
+// The native logger can only be initialized once per process, the bridge has to be closed when the SDK is closed.
+func (i *IOTASDK) InitSlogLogger(logger *slog.Logger, loggerConfig types.ILoggerConfig) (*NativeLogBridge, error) {
+	tempDir, err := os.MkdirTemp("", "iota-sdk-log-")
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(tempDir, "native.log")
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		return nil, err
+	}
+
+	bridge := newNativeLogBridge(logger, file)
+	bridge.tempDir = tempDir
+	bridge.truncate = true
+
+	go bridge.run()
+
+	loggerConfig.Name = path
+	loggerConfig.ColorEnabled = false
+	if _, err := i.InitLogger(loggerConfig); err != nil {
+		return nil, errors.Join(err, bridge.Close())
+	}
+
+	return bridge, nil
+}
+
+// TailNativeLog forwards the records the native logger appends to the file at path, e.g. one configured with InitLogger
+func TailNativeLog(logger *slog.Logger, path string) (*NativeLogBridge, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only new records are forwarded
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	bridge := newNativeLogBridge(logger, file)
+
+	go bridge.run()
+
+	return bridge, nil
+}
+
+func newNativeLogBridge(logger *slog.Logger, file *os.File) *NativeLogBridge {
+	return &NativeLogBridge{
+		logger: logger,
+		file:   file,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Path returns the path of the log file the bridge reads
+func (b *NativeLogBridge) Path() string {
+	return b.file.Name()
+}
+
+// Close forwards the remaining records and stops the bridge, the temporary log file of InitSlogLogger is removed
+func (b *NativeLogBridge) Close() error {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+	})
+	<-b.done
+
+	err := b.file.Close()
+	if b.tempDir != "" {
+		err = errors.Join(err, os.RemoveAll(b.tempDir))
+	}
+
+	return err
+}
+
+func (b *NativeLogBridge) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(nativeLogPollInterval)
+	defer ticker.Stop()
+
+	for {
+		b.forward()
+
+		select {
+		case <-b.stop:
+			b.forward()
+
+			// The last line may not be terminated yet
+			if len(b.pending) > 0 {
+				b.handleLine(string(b.pending))
+				b.pending = nil
+			}
+			b.flush(true)
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// forward reads the lines appended since the last call, a record ends with the next record or when no more lines are available
+func (b *NativeLogBridge) forward() {
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := b.file.Read(buffer)
+		b.pending = append(b.pending, buffer[:n]...)
+
+		for {
+			index := bytes.IndexByte(b.pending, '\n')
+			if index < 0 {
+				break
+			}
+
+			b.handleLine(strings.TrimRight(string(b.pending[:index]), "\r"))
+			b.pending = b.pending[index+1:]
+		}
+
+		if err != nil || n == 0 {
+			break
+		}
+	}
+
+	if b.truncate {
+		b.truncateRead()
+	}
+
+	b.flush(false)
+}
+
+// truncateRead empties the file once everything was read, so raw records don't pile up on disk.
+// The native logger appends to the file, so it continues at its start.
+// A record appended between the size check and the truncation is lost, the window is a single system call.
+func (b *NativeLogBridge) truncateRead() {
+	offset, err := b.file.Seek(0, io.SeekCurrent)
+	if err != nil || offset == 0 {
+		return
+	}
+
+	info, err := b.file.Stat()
+	if err != nil || info.Size() != offset {
+		return
+	}
+
+	if err := b.file.Truncate(0); err == nil {
+		_, _ = b.file.Seek(0, io.SeekStart)
+	}
+}
+
+func (b *NativeLogBridge) handleLine(line string) {
+	line = ansiEscapePattern.ReplaceAllString(line, "")
+
+	match := nativeLogLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		// Continuation of a multi-line message, or output the bridge doesn't know
+		if b.record == nil {
+			b.startRecord(time.Now(), slog.LevelInfo, "", line)
+		} else {
+			b.record.Message += "\n" + line
+		}
+		return
+	}
+
+	b.flush(true)
+
+	timestamp, err := time.ParseInLocation(time.DateTime, match[1]+" "+match[2], time.Local)
+	if err != nil {
+		timestamp = time.Now()
+	}
+
+	b.startRecord(timestamp, nativeLogLevels[match[4]], match[3], match[5])
+}
+
+func (b *NativeLogBridge) startRecord(timestamp time.Time, level slog.Level, target string, message string) {
+	record := slog.NewRecord(timestamp, level, message, 0)
+	if target != "" {
+		record.AddAttrs(slog.String("target", target))
+	}
+
+	b.record = &record
+}
+
+// flush forwards the current record. Without force it's kept while lines are pending, they may continue it.
+func (b *NativeLogBridge) flush(force bool) {
+	if b.record == nil || (!force && len(b.pending) > 0) {
+		return
+	}
+
+	record := *b.record
+	b.record = nil
+
+	ctx := context.Background()
+	if !b.logger.Enabled(ctx, record.Level) {
+		return
+	}
+
+	record.Message = RedactSecrets(record.Message)
+	_ = b.logger.Handler().Handle(ctx, record)
+}
+
+// SetLogger enables debug logging of the message bus calls, with their method names and durations.
+// Payloads are never logged and errors are redacted with RedactSecrets. A nil logger disables it.
+func (i *IOTASDK) SetLogger(logger *slog.Logger) {
+	i.logger.Store(logger)
+}
+
+func (i *IOTASDK) logMethodCall(domain Domain, method any, duration time.Duration, err error) {
+	logger := i.logger.Load()
+	if logger == nil || !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("domain", string(domain)),
+		slog.String("method", methodName(method)),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		// Native errors may echo the request, e.g. a mnemonic that failed to verify
+		attrs = append(attrs, slog.String("error", RedactSecrets(err.Error())))
+	}
+
+	logger.LogAttrs(context.Background(), slog.LevelDebug, "native method call", attrs...)
+}
+
+func methodName(method any) string {
+	if named, ok := method.(interface{ MethodName() string }); ok {
+		return named.MethodName()
+	}
+
+	return "unknown"
+}
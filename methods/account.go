@@ -0,0 +1,160 @@
+package methods
+
+import "github.com/iotaledger/wasp-wallet-sdk/types"
+
+func NewAccountMethod[T any](name string, data T) types.BaseCallAccountMethodWrap[any] {
+	return types.BaseCallAccountMethodWrap[any]{
+		Name: name,
+		Data: data,
+	}
+}
+
+func NewAccountMethodNoData(name string) types.BaseCallAccountMethodWrap[any] {
+	return types.BaseCallAccountMethodWrap[any]{
+		Name: name,
+	}
+}
+
+func SignAndSubmitTransactionMethod(data SignAndSubmitTransactionMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "signAndSubmitTransaction"
+
+	return NewAccountMethod(method, data)
+}
+
+func UnspentOutputsMethod(data OutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "unspentOutputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func OutputsMethod(data OutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "outputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareBurnMethod(data PrepareBurnMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareBurn"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareMintNftsMethod(data PrepareMintNftsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareMintNfts"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareSendNftMethod(data PrepareSendNftMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareSendNft"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareSendMethod(data PrepareSendMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareSend"
+
+	return NewAccountMethod(method, data)
+}
+
+func SyncMethod(data SyncMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "sync"
+
+	return NewAccountMethod(method, data)
+}
+
+func GetBalanceMethod() types.BaseCallAccountMethodWrap[any] {
+	method := "getBalance"
+
+	return NewAccountMethodNoData(method)
+}
+
+func RetryTransactionUntilIncludedMethod(data RetryTransactionUntilIncludedMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "retryTransactionUntilIncluded"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareCreateAliasOutputMethod(data PrepareCreateAliasOutputMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareCreateAliasOutput"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareMintNativeTokenMethod(data PrepareMintNativeTokenMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareMintNativeToken"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareIncreaseNativeTokenSupplyMethod(data PrepareIncreaseNativeTokenSupplyMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareIncreaseNativeTokenSupply"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareDecreaseNativeTokenSupplyMethod(data PrepareDecreaseNativeTokenSupplyMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareDecreaseNativeTokenSupply"
+
+	return NewAccountMethod(method, data)
+}
+
+func SendOutputsMethod(data SendOutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "sendOutputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func ClaimableOutputsMethod(data ClaimableOutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "claimableOutputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func ClaimOutputsMethod(data ClaimOutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "claimOutputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func GetOutputMethod(data GetOutputMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "getOutput"
+
+	return NewAccountMethod(method, data)
+}
+
+func PrepareConsolidateOutputsMethod(data PrepareConsolidateOutputsMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "prepareConsolidateOutputs"
+
+	return NewAccountMethod(method, data)
+}
+
+func TransactionsMethod() types.BaseCallAccountMethodWrap[any] {
+	method := "transactions"
+
+	return NewAccountMethodNoData(method)
+}
+
+func IncomingTransactionsMethod() types.BaseCallAccountMethodWrap[any] {
+	method := "incomingTransactions"
+
+	return NewAccountMethodNoData(method)
+}
+
+func PendingTransactionsMethod() types.BaseCallAccountMethodWrap[any] {
+	method := "pendingTransactions"
+
+	return NewAccountMethodNoData(method)
+}
+
+func GetTransactionMethod(data GetTransactionMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "getTransaction"
+
+	return NewAccountMethod(method, data)
+}
+
+func GetIncomingTransactionMethod(data GetTransactionMethodData) types.BaseCallAccountMethodWrap[any] {
+	method := "getIncomingTransaction"
+
+	return NewAccountMethod(method, data)
+}
@@ -0,0 +1,132 @@
+package methods
+
+import (
+	"encoding/json"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type SignAndSubmitTransactionMethodData struct {
+	// PreparedTransactionData as returned by a prepare method.
+	// It's passed through unmodified, as only the SDK needs to understand it.
+	PreparedTransactionData json.RawMessage `json:"preparedTransactionData" yaml:"preparedTransactionData" mapstructure:"preparedTransactionData"`
+}
+
+type OutputsMethodData struct {
+	// FilterOptions corresponds to the JSON schema field "filterOptions".
+	FilterOptions *types.FilterOptions `json:"filterOptions,omitempty" yaml:"filterOptions,omitempty" mapstructure:"filterOptions,omitempty"`
+}
+
+type PrepareBurnMethodData struct {
+	// Burn corresponds to the JSON schema field "burn".
+	Burn types.Burn `json:"burn" yaml:"burn" mapstructure:"burn"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareMintNftsMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params []types.MintNftParams `json:"params" yaml:"params" mapstructure:"params"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareSendMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params []types.SendParams `json:"params" yaml:"params" mapstructure:"params"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareSendNftMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params []types.SendNftParams `json:"params" yaml:"params" mapstructure:"params"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type SyncMethodData struct {
+	// Options corresponds to the JSON schema field "options".
+	Options *types.SyncOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type RetryTransactionUntilIncludedMethodData struct {
+	// Interval between the retries in seconds
+	Interval uint64 `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
+
+	// MaxAttempts corresponds to the JSON schema field "maxAttempts".
+	MaxAttempts uint64 `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty" mapstructure:"maxAttempts,omitempty"`
+
+	// TransactionId corresponds to the JSON schema field "transactionId".
+	TransactionId types.HexEncodedString `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
+
+type PrepareCreateAliasOutputMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params *types.AliasOutputParams `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareMintNativeTokenMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params types.MintNativeTokenParams `json:"params" yaml:"params" mapstructure:"params"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareIncreaseNativeTokenSupplyMethodData struct {
+	// TokenId corresponds to the JSON schema field "tokenId".
+	TokenId types.HexEncodedString `json:"tokenId" yaml:"tokenId" mapstructure:"tokenId"`
+
+	// MintAmount corresponds to the JSON schema field "mintAmount".
+	MintAmount types.HexEncodedAmount `json:"mintAmount" yaml:"mintAmount" mapstructure:"mintAmount"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PrepareDecreaseNativeTokenSupplyMethodData struct {
+	// TokenId corresponds to the JSON schema field "tokenId".
+	TokenId types.HexEncodedString `json:"tokenId" yaml:"tokenId" mapstructure:"tokenId"`
+
+	// MeltAmount corresponds to the JSON schema field "meltAmount".
+	MeltAmount types.HexEncodedAmount `json:"meltAmount" yaml:"meltAmount" mapstructure:"meltAmount"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type SendOutputsMethodData struct {
+	// Outputs corresponds to the JSON schema field "outputs".
+	Outputs []types.OutputDataOutput `json:"outputs" yaml:"outputs" mapstructure:"outputs"`
+
+	// Options corresponds to the JSON schema field "options".
+	Options *types.TransactionOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type ClaimableOutputsMethodData struct {
+	// OutputsToClaim corresponds to the JSON schema field "outputsToClaim".
+	OutputsToClaim types.ClaimableOutputsFilter `json:"outputsToClaim" yaml:"outputsToClaim" mapstructure:"outputsToClaim"`
+}
+
+type GetOutputMethodData struct {
+	// OutputId corresponds to the JSON schema field "outputId".
+	OutputId types.OutputId `json:"outputId" yaml:"outputId" mapstructure:"outputId"`
+}
+
+type PrepareConsolidateOutputsMethodData struct {
+	// Params corresponds to the JSON schema field "params".
+	Params types.ConsolidationParams `json:"params" yaml:"params" mapstructure:"params"`
+}
+
+type GetTransactionMethodData struct {
+	// TransactionId corresponds to the JSON schema field "transactionId".
+	TransactionId types.HexEncodedString `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
@@ -17,6 +17,15 @@ type BaseRequest[T any] struct {
 	Data T      `json:"data" yaml:"data" mapstructure:"data"`
 }
 
+// MethodName returns the name of the method, including the name of a wrapped account method
+func (r BaseRequest[T]) MethodName() string {
+	if wrapped, ok := any(r.Data).(interface{ MethodName() string }); ok {
+		return r.Name + "." + wrapped.MethodName()
+	}
+
+	return r.Name
+}
+
 func NewBaseRequest[T any](name string, data T) BaseRequest[T] {
 	return BaseRequest[T]{
 		Name: name,
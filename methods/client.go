@@ -0,0 +1,19 @@
+package methods
+
+func BuildAndPostBlockMethod[T BuildAndPostBlockMethodData](data T) BaseRequest[T] {
+	method := "buildAndPostBlock"
+
+	return NewBaseRequest(method, data)
+}
+
+func PostBlockRawMethod[T PostBlockRawMethodData](data T) BaseRequest[T] {
+	method := "postBlockRaw"
+
+	return NewBaseRequest(method, data)
+}
+
+func GetBlockMetadataMethod[T GetBlockMetadataMethodData](data T) BaseRequest[T] {
+	method := "getBlockMetadata"
+
+	return NewBaseRequest(method, data)
+}
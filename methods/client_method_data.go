@@ -0,0 +1,34 @@
+package methods
+
+import (
+	"encoding/json"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type BuildAndPostBlockMethodData struct {
+	// Options corresponds to the JSON schema field "options".
+	Options *types.BuildBlockOptions `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+}
+
+type PostBlockRawMethodData struct {
+	// BlockBytes are the serialized block
+	BlockBytes ByteArray `json:"blockBytes" yaml:"blockBytes" mapstructure:"blockBytes"`
+}
+
+type GetBlockMetadataMethodData struct {
+	// BlockId corresponds to the JSON schema field "blockId".
+	BlockId types.HexEncodedString `json:"blockId" yaml:"blockId" mapstructure:"blockId"`
+}
+
+// ByteArray is serialized as an array of numbers, as the SDK expects instead of base64
+type ByteArray []byte
+
+func (b ByteArray) MarshalJSON() ([]byte, error) {
+	numbers := make([]uint16, len(b))
+	for index, value := range b {
+		numbers[index] = uint16(value)
+	}
+
+	return json.Marshal(numbers)
+}
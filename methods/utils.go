@@ -5,3 +5,15 @@ func GenerateMnemonicMethod() BaseRequest[NoType] {
 
 	return NewBaseRequestNoData(method)
 }
+
+func ParseBech32AddressMethod[T ParseBech32AddressMethodData](data T) BaseRequest[T] {
+	method := "parseBech32Address"
+
+	return NewBaseRequest(method, data)
+}
+
+func VerifyMnemonicMethod[T VerifyMnemonicMethodData](data T) BaseRequest[T] {
+	method := "verifyMnemonic"
+
+	return NewBaseRequest(method, data)
+}
@@ -0,0 +1,11 @@
+package methods
+
+type ParseBech32AddressMethodData struct {
+	// Address corresponds to the JSON schema field "address".
+	Address string `json:"address" yaml:"address" mapstructure:"address"`
+}
+
+type VerifyMnemonicMethodData struct {
+	// Mnemonic corresponds to the JSON schema field "mnemonic".
+	Mnemonic string `json:"mnemonic" yaml:"mnemonic" mapstructure:"mnemonic"`
+}
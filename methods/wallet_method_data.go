@@ -35,11 +35,14 @@ type CreateAccountPayloadMethodData struct {
 
 	// Bech32Hrp corresponds to the JSON schema field "bech32Hrp".
 	Bech32Hrp string `json:"bech32Hrp,omitempty" yaml:"bech32Hrp,omitempty" mapstructure:"bech32Hrp,omitempty"`
+
+	// Addresses corresponds to the JSON schema field "addresses".
+	Addresses []types.AccountAddress `json:"addresses,omitempty" yaml:"addresses,omitempty" mapstructure:"addresses,omitempty"`
 }
 
 type GetAccountMethodData struct {
 	// AccountID corresponds to the JSON schema field "accountId".
-	AccountID string `json:"accountId" yaml:"accountId" mapstructure:"accountId"`
+	AccountID types.AccountIdentifier `json:"accountId" yaml:"accountId" mapstructure:"accountId"`
 }
 
 type SetDefaultSyncOptionsMethodData struct {
@@ -0,0 +1,514 @@
+package wasp_wallet_sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/awnumar/memguard"
+)
+
+// Native functions captured by a recording, they're replayed in the recorded order
+const (
+	callCreateClient               = "create_client"
+	callCreateWallet               = "create_wallet"
+	callCreateSecretManager        = "create_secret_manager"
+	callGetClientFromWallet        = "get_client_from_wallet"
+	callGetSecretManagerFromWallet = "get_secret_manager_from_wallet"
+	callClientMethod               = "call_client_method"
+	callWalletMethod               = "call_wallet_method"
+	callSecretManagerMethod        = "call_secret_manager_method"
+	callUtilsMethod                = "call_utils_method"
+)
+
+var ErrReplayMismatch = errors.New("replayed call doesn't match the recording")
+
+// RecordedCall is a native call with its redacted request and response.
+// Only secret fields and mnemonics are redacted from requests, so calls with other messages, IDs or keys don't match.
+// Handles are numbered in the order they were created, and per-run paths of requests are normalized, so recordings are deterministic.
+type RecordedCall struct {
+	Call     string          `json:"call"`
+	Handle   uint64          `json:"handle,omitempty"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Result is the handle created by the call
+	Result uint64 `json:"result,omitempty"`
+
+	// Error is the last error of the library if the call failed
+	Error string `json:"error,omitempty"`
+}
+
+type recordingFile struct {
+	Calls []RecordedCall `json:"calls"`
+}
+
+// Recording captures the native calls of an SDK into a golden file for NewReplaySDK.
+// Secret fields and mnemonics of requests and mnemonics of responses are redacted.
+type Recording struct {
+	mutex   sync.Mutex
+	calls   []RecordedCall
+	handles map[uintptr]uint64
+	failed  int
+}
+
+// StartRecording captures all native calls from now on, it has to be started before any wallet, client or secret manager is created
+func (i *IOTASDK) StartRecording() *Recording {
+	recording := &Recording{
+		handles: make(map[uintptr]uint64),
+		failed:  -1,
+	}
+
+	createClient, createWallet, createSecretManager := i.libCreateClient, i.libCreateWallet, i.libCreateSecretManager
+	if createClient != nil {
+		i.libCreateClient = func(msg *byte) IotaClientPtr {
+			return IotaClientPtr(recording.recordCreate(callCreateClient, msg, func() uintptr { return uintptr(createClient(msg)) }))
+		}
+	}
+	if createWallet != nil {
+		i.libCreateWallet = func(msg *byte) IotaWalletPtr {
+			return IotaWalletPtr(recording.recordCreate(callCreateWallet, msg, func() uintptr { return uintptr(createWallet(msg)) }))
+		}
+	}
+	i.libCreateSecretManager = func(msg *byte) IotaSecretManagerPtr {
+		return IotaSecretManagerPtr(recording.recordCreate(callCreateSecretManager, msg, func() uintptr { return uintptr(createSecretManager(msg)) }))
+	}
+
+	getClientFromWallet, getSecretManagerFromWallet := i.libGetClientFromWallet, i.libGetSecretManagerFromWallet
+	if getClientFromWallet != nil {
+		i.libGetClientFromWallet = func(wallet IotaWalletPtr) IotaClientPtr {
+			return IotaClientPtr(recording.recordDerive(callGetClientFromWallet, uintptr(wallet), func() uintptr { return uintptr(getClientFromWallet(wallet)) }))
+		}
+	}
+	if getSecretManagerFromWallet != nil {
+		i.libGetSecretManagerFromWallet = func(wallet IotaWalletPtr) IotaSecretManagerPtr {
+			return IotaSecretManagerPtr(recording.recordDerive(callGetSecretManagerFromWallet, uintptr(wallet), func() uintptr { return uintptr(getSecretManagerFromWallet(wallet)) }))
+		}
+	}
+
+	callClient, callWallet, callSecretManager, callUtils := i.libCallClientMethod, i.libCallWalletMethod, i.libCallSecretManagerMethod, i.libCallUtilsMethod
+	if callClient != nil {
+		i.libCallClientMethod = func(client IotaClientPtr, msg *byte) uintptr {
+			return recording.recordMethod(callClientMethod, uintptr(client), msg, func() uintptr { return callClient(client, msg) })
+		}
+	}
+	if callWallet != nil {
+		i.libCallWalletMethod = func(wallet IotaWalletPtr, msg *byte) uintptr {
+			return recording.recordMethod(callWalletMethod, uintptr(wallet), msg, func() uintptr { return callWallet(wallet, msg) })
+		}
+	}
+	i.libCallSecretManagerMethod = func(secretManager IotaSecretManagerPtr, msg *byte) uintptr {
+		return recording.recordMethod(callSecretManagerMethod, uintptr(secretManager), msg, func() uintptr { return callSecretManager(secretManager, msg) })
+	}
+	i.libCallUtilsMethod = func(msg *byte) uintptr {
+		return recording.recordMethod(callUtilsMethod, 0, msg, func() uintptr { return callUtils(msg) })
+	}
+
+	// The error of a failed call is only known once the SDK asks for it
+	getLastError := i.libGetLastError
+	i.libGetLastError = func() string {
+		lastError := getLastError()
+		recording.recordError(lastError)
+
+		return lastError
+	}
+
+	return recording
+}
+
+// Calls returns the calls recorded so far
+func (r *Recording) Calls() []RecordedCall {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// Save writes the recording as golden file
+func (r *Recording) Save(path string) error {
+	data, err := json.MarshalIndent(recordingFile{Calls: r.Calls()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func (r *Recording) recordCreate(call string, msg *byte, create func() uintptr) uintptr {
+	request := redactRequest(msg)
+	ptr := create()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.append(RecordedCall{Call: call, Request: request, Result: r.handle(ptr)}, ptr == 0)
+
+	return ptr
+}
+
+func (r *Recording) recordDerive(call string, parent uintptr, derive func() uintptr) uintptr {
+	ptr := derive()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.append(RecordedCall{Call: call, Handle: r.handles[parent], Result: r.handle(ptr)}, ptr == 0)
+
+	return ptr
+}
+
+func (r *Recording) recordMethod(call string, handle uintptr, msg *byte, invoke func() uintptr) uintptr {
+	request := redactRequest(msg)
+	responsePtr := invoke()
+
+	var response json.RawMessage
+	if responsePtr != 0 {
+		data, free := GoString(responsePtr)
+		response = redactJSON(data, redactMnemonics)
+		free()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.append(RecordedCall{Call: call, Handle: r.handles[handle], Request: request, Response: response}, responsePtr == 0)
+
+	return responsePtr
+}
+
+// append adds the call, a failed call gets the error of the next GetLastError
+func (r *Recording) append(call RecordedCall, failed bool) {
+	r.calls = append(r.calls, call)
+
+	r.failed = -1
+	if failed {
+		r.failed = len(r.calls) - 1
+	}
+}
+
+func (r *Recording) recordError(lastError string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.failed >= 0 {
+		r.calls[r.failed].Error = RedactSecrets(lastError)
+		r.failed = -1
+	}
+}
+
+// handle returns the number of the native handle, new handles are numbered in order
+func (r *Recording) handle(ptr uintptr) uint64 {
+	if ptr == 0 {
+		return 0
+	}
+
+	if number, ok := r.handles[ptr]; ok {
+		return number
+	}
+
+	number := uint64(len(r.handles) + 1)
+	r.handles[ptr] = number
+
+	return number
+}
+
+// replay serves recorded calls in their order
+type replay struct {
+	mutex     sync.Mutex
+	path      string
+	calls     []RecordedCall
+	next      int
+	lastError string
+
+	// responses are kept until the SDK destroys them
+	responses map[uintptr][]byte
+}
+
+// NewReplaySDK returns an SDK that serves the calls of a golden file written by Recording.Save, without the native library.
+// Calls have to be made in the recorded order, others fail with ErrReplayMismatch and a diff of the requests.
+func NewReplaySDK(goldenPath string) (*IOTASDK, error) {
+	data, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var file recordingFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", goldenPath, err)
+	}
+
+	// Requests are compared in their canonical form, golden files may be formatted
+	for index, call := range file.Calls {
+		if len(call.Request) > 0 {
+			file.Calls[index].Request = canonicalJSON(call.Request)
+		}
+	}
+
+	r := &replay{
+		path:      goldenPath,
+		calls:     file.Calls,
+		responses: make(map[uintptr][]byte),
+	}
+
+	sdk := &IOTASDK{
+		handles: newHandleRegistry(),
+		capabilities: Capabilities{
+			Logger:       true,
+			Client:       true,
+			Wallet:       true,
+			WalletEvents: true,
+		},
+	}
+
+	sdk.libInitLogger = func(*byte) bool { return true }
+	sdk.libListenWallet = func(IotaWalletPtr, *byte, uintptr) bool { return true }
+	sdk.libDestroyClient = func(IotaClientPtr) bool { return true }
+	sdk.libDestroyWallet = func(IotaWalletPtr) bool { return true }
+	sdk.libDestroySecretManager = func(IotaSecretManagerPtr) bool { return true }
+	sdk.libDestroyString = r.destroyString
+	sdk.libGetLastError = r.getLastError
+
+	sdk.libCreateClient = func(msg *byte) IotaClientPtr {
+		return IotaClientPtr(r.create(callCreateClient, msg))
+	}
+	sdk.libCreateWallet = func(msg *byte) IotaWalletPtr {
+		return IotaWalletPtr(r.create(callCreateWallet, msg))
+	}
+	sdk.libCreateSecretManager = func(msg *byte) IotaSecretManagerPtr {
+		return IotaSecretManagerPtr(r.create(callCreateSecretManager, msg))
+	}
+	sdk.libGetClientFromWallet = func(wallet IotaWalletPtr) IotaClientPtr {
+		return IotaClientPtr(r.derive(callGetClientFromWallet, uintptr(wallet)))
+	}
+	sdk.libGetSecretManagerFromWallet = func(wallet IotaWalletPtr) IotaSecretManagerPtr {
+		return IotaSecretManagerPtr(r.derive(callGetSecretManagerFromWallet, uintptr(wallet)))
+	}
+	sdk.libCallClientMethod = func(client IotaClientPtr, msg *byte) uintptr {
+		return r.method(callClientMethod, uintptr(client), msg)
+	}
+	sdk.libCallWalletMethod = func(wallet IotaWalletPtr, msg *byte) uintptr {
+		return r.method(callWalletMethod, uintptr(wallet), msg)
+	}
+	sdk.libCallSecretManagerMethod = func(secretManager IotaSecretManagerPtr, msg *byte) uintptr {
+		return r.method(callSecretManagerMethod, uintptr(secretManager), msg)
+	}
+	sdk.libCallUtilsMethod = func(msg *byte) uintptr {
+		return r.method(callUtilsMethod, 0, msg)
+	}
+
+	return sdk, nil
+}
+
+func (r *replay) create(call string, msg *byte) uintptr {
+	recorded, ok := r.take(RecordedCall{Call: call, Request: redactRequest(msg)})
+	if !ok {
+		return 0
+	}
+
+	return uintptr(recorded.Result)
+}
+
+func (r *replay) derive(call string, parent uintptr) uintptr {
+	recorded, ok := r.take(RecordedCall{Call: call, Handle: uint64(parent)})
+	if !ok {
+		return 0
+	}
+
+	return uintptr(recorded.Result)
+}
+
+func (r *replay) method(call string, handle uintptr, msg *byte) uintptr {
+	recorded, ok := r.take(RecordedCall{Call: call, Handle: uint64(handle), Request: redactRequest(msg)})
+	if !ok || recorded.Response == nil {
+		return 0
+	}
+
+	// The response is handed out as C string, it's kept referenced until destroy_string
+	response := append(append([]byte(nil), recorded.Response...), 0)
+	ptr := uintptr(unsafe.Pointer(&response[0]))
+
+	r.mutex.Lock()
+	r.responses[ptr] = response
+	r.mutex.Unlock()
+
+	return ptr
+}
+
+// take returns the next recorded call if it matches the actual call, failed calls set the last error
+func (r *replay) take(actual RecordedCall) (RecordedCall, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.next >= len(r.calls) {
+		r.lastError = fmt.Sprintf("%v: %s has no more calls, got %s\n%s", ErrReplayMismatch, r.path, actual.Call, indentJSON(actual.Request))
+		return RecordedCall{}, false
+	}
+
+	expected := r.calls[r.next]
+	if expected.Call != actual.Call || expected.Handle != actual.Handle || !bytes.Equal(expected.Request, actual.Request) {
+		r.lastError = fmt.Sprintf("%v: call %d of %s\n%s", ErrReplayMismatch, r.next, r.path, diffLines(describeCall(expected), describeCall(actual)))
+		return RecordedCall{}, false
+	}
+	r.next++
+
+	if expected.Error != "" {
+		r.lastError = expected.Error
+		return expected, false
+	}
+
+	return expected, true
+}
+
+func (r *replay) destroyString(ptr uintptr) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	response, ok := r.responses[ptr]
+	if ok {
+		memguard.WipeBytes(response)
+		delete(r.responses, ptr)
+	}
+
+	return ok
+}
+
+func (r *replay) getLastError() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.lastError
+}
+
+// redactRequest reads the serialized message, redacts its secret fields and mnemonics and normalizes it, the copy of the message is wiped.
+// Other values are kept, e.g. the messages to sign and transaction IDs, replayed calls have to match them.
+func redactRequest(msg *byte) json.RawMessage {
+	data, free := GoString(uintptr(unsafe.Pointer(msg)))
+	defer free()
+
+	return redactJSON(data, func(value string) string {
+		return normalizePaths(redactMnemonics(value))
+	})
+}
+
+// normalizePaths replaces the parts of paths that change between runs and machines.
+// The working directory becomes [WORKDIR], and the first directory below the temporary directory,
+// e.g. the one of t.TempDir(), becomes [TMPDIR].
+func normalizePaths(value string) string {
+	if workDir, err := os.Getwd(); err == nil && len(workDir) > 1 {
+		value = strings.ReplaceAll(value, workDir, "[WORKDIR]")
+	}
+
+	tempDir := filepath.Clean(os.TempDir()) + string(filepath.Separator)
+	for {
+		index := strings.Index(value, tempDir)
+		if index < 0 {
+			return value
+		}
+
+		rest := value[index+len(tempDir):]
+		end := strings.IndexAny(rest, `/\`)
+		if end < 0 {
+			end = len(rest)
+		}
+
+		value = value[:index] + "[TMPDIR]" + rest[end:]
+	}
+}
+
+// redactJSON returns the canonical form of the redacted JSON value, invalid JSON is redacted as a whole
+func redactJSON(data []byte, redactString func(string) string) json.RawMessage {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		value = redactString(string(data))
+	}
+
+	redactedData, err := json.Marshal(redactPayloadValue(value, redactString))
+	if err != nil {
+		return nil
+	}
+
+	return redactedData
+}
+
+// canonicalJSON returns the compact form of the JSON value with sorted keys
+func canonicalJSON(data []byte) json.RawMessage {
+	return redactJSON(data, func(value string) string {
+		return value
+	})
+}
+
+// redactMnemonics only redacts mnemonics, requests and responses contain messages, public keys and IDs that look like private keys
+func redactMnemonics(message string) string {
+	return mnemonicPattern.ReplaceAllString(message, redacted)
+}
+
+func indentJSON(data json.RawMessage) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, data, "", "  "); err != nil {
+		return string(data)
+	}
+
+	return buffer.String()
+}
+
+func describeCall(call RecordedCall) string {
+	description := fmt.Sprintf("%s handle=%d", call.Call, call.Handle)
+	if len(call.Request) > 0 {
+		description += "\n" + indentJSON(call.Request)
+	}
+
+	return description
+}
+
+// diffLines returns a line diff of the recorded and the actual call, based on their longest common subsequence
+func diffLines(expected string, actual string) string {
+	a, b := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	diff.WriteString("--- recorded\n+++ actual\n")
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j >= len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+
+	return diff.String()
+}
@@ -2,6 +2,8 @@ package wasp_wallet_sdk
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/awnumar/memguard"
 
@@ -12,6 +14,22 @@ import (
 type SecretManager struct {
 	sdk              *IOTASDK
 	secretManagerPtr IotaSecretManagerPtr
+	handle           *nativeHandle
+
+	// closed is set by Close, calls fail with ErrHandleClosed afterwards
+	closed atomic.Bool
+}
+
+func newSecretManager(sdk *IOTASDK, handle *nativeHandle) *SecretManager {
+	secretManager := &SecretManager{
+		sdk:              sdk,
+		secretManagerPtr: IotaSecretManagerPtr(handle.key.ptr),
+		handle:           handle,
+	}
+
+	watchLeak(sdk.handles, secretManager, handle)
+
+	return secretManager
 }
 
 // NewMnemonicSecretManager creates or opens an in-memory Mnemonic based secret storage
@@ -29,10 +47,7 @@ func NewMnemonicSecretManager(sdk *IOTASDK, mnemonic *memguard.Enclave) (*Secret
 		return nil, err
 	}
 
-	return &SecretManager{
-		sdk:              sdk,
-		secretManagerPtr: secretManagerPtr,
-	}, nil
+	return newSecretManager(sdk, sdk.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), nil)), nil
 }
 
 func NewStrongholdSecretManager(sdk *IOTASDK, password *memguard.Enclave, snapshotPath string) (*SecretManager, error) {
@@ -52,10 +67,7 @@ func NewStrongholdSecretManager(sdk *IOTASDK, password *memguard.Enclave, snapsh
 		return nil, err
 	}
 
-	return &SecretManager{
-		sdk:              sdk,
-		secretManagerPtr: secretManagerPtr,
-	}, nil
+	return newSecretManager(sdk, sdk.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), nil)), nil
 }
 
 // NewLedgerSecretManager creates or opens a Ledger based secret storage
@@ -67,18 +79,26 @@ func NewLedgerSecretManager(sdk *IOTASDK, isEmulator bool) (*SecretManager, erro
 		return nil, err
 	}
 
-	return &SecretManager{
-		sdk:              sdk,
-		secretManagerPtr: secretManagerPtr,
-	}, nil
+	return newSecretManager(sdk, sdk.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), nil)), nil
+}
+
+// Close releases the secret manager, it's destroyed once it has no owner left.
+// Calls that are still running keep it alive until they returned, later calls fail with ErrHandleClosed.
+func (s *SecretManager) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+
+	return s.sdk.releaseTrackedHandle(s.handle)
 }
 
+// Destroy is Close without reporting errors
 func (s *SecretManager) Destroy() {
-	_ = s.sdk.DestroySecretManager(s.secretManagerPtr)
+	_ = s.Close()
 }
 
 func (s *SecretManager) GetLedgerStatus() (*types.LedgerNanoStatus, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.GetLedgerNanoStatusMethod())
+	ledgerNanoStatus, free, err := s.callMethod(methods.GetLedgerNanoStatusMethod())
 	defer free()
 	if err != nil {
 		return nil, err
@@ -92,26 +112,8 @@ func (s *SecretManager) GetLedgerStatus() (*types.LedgerNanoStatus, error) {
 	return status, nil
 }
 
-func (s *SecretManager) CreateAccount(bech32Hrp string, alias string) (any, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.CreateAccountMethod(methods.CreateAccountPayloadMethodData{
-		Bech32Hrp: bech32Hrp,
-		Alias:     alias,
-	}))
-	defer free()
-	if err != nil {
-		return "", err
-	}
-
-	address, err := methods.ParseResponse[any](ledgerNanoStatus, err)
-	if err != nil || address == nil {
-		return "", err
-	}
-
-	return *address, nil
-}
-
 func (s *SecretManager) GenerateEvmAddresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, options *types.IGenerateAddressOptions) ([]string, error) {
-	evmAddresses, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.GenerateEVMAddressMethod(methods.GenerateEvmAddressesMethodData{
+	evmAddresses, free, err := s.callMethod(methods.GenerateEVMAddressMethod(methods.GenerateEvmAddressesMethodData{
 		Options: types.IGenerateAddressesOptions{
 			AccountIndex: accountIndex,
 			Bech32Hrp:    bech32Hrp,
@@ -133,8 +135,14 @@ func (s *SecretManager) GenerateEvmAddresses(addressRange types.Range, accountIn
 	return *address, nil
 }
 
+// GenerateEd25519Addresses generates a range of addresses, an empty bech32Hrp defaults to the HRP of the coin type
 func (s *SecretManager) GenerateEd25519Addresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) ([]string, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.GenerateEd25519AddressesMethod(methods.GenerateEd25519AddressesMethodData{
+	bech32Hrp, err := types.ResolveBech32Hrp(coinType, bech32Hrp)
+	if err != nil {
+		return []string{}, err
+	}
+
+	ledgerNanoStatus, free, err := s.callMethod(methods.GenerateEd25519AddressesMethod(methods.GenerateEd25519AddressesMethodData{
 		Options: types.IGenerateAddressesOptions{
 			AccountIndex: accountIndex,
 			Bech32Hrp:    bech32Hrp,
@@ -168,6 +176,7 @@ func (s *SecretManager) GenerateEd25519Address(addressIndex uint32, accountIndex
 	return addresses[0], nil
 }
 
+// StoreMnemonic stores the mnemonic in a Stronghold snapshot, other secret managers don't support it
 func (s *SecretManager) StoreMnemonic(mnemonic *memguard.Enclave) (bool, error) {
 	buffer, err := mnemonic.Open()
 	if err != nil {
@@ -175,7 +184,7 @@ func (s *SecretManager) StoreMnemonic(mnemonic *memguard.Enclave) (bool, error)
 	}
 	defer buffer.Destroy()
 
-	success, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.StoreMnemonicMethod(methods.StoreMnemonicMethodData{
+	success, free, err := s.callMethod(methods.StoreMnemonicMethod(methods.StoreMnemonicMethodData{
 		Mnemonic: buffer.String(),
 	}))
 	defer free()
@@ -187,7 +196,7 @@ func (s *SecretManager) StoreMnemonic(mnemonic *memguard.Enclave) (bool, error)
 }
 
 func (s *SecretManager) SignTransactionEssence(txEssence types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Ed25519Signature, error) {
-	signedMessageStr, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.SignEd25519Method(methods.SignEd25519MethodData{
+	signedMessageStr, free, err := s.callMethod(methods.SignEd25519Method(methods.SignEd25519MethodData{
 		Message: txEssence,
 		Chain:   bip44Chain,
 	}))
@@ -198,3 +207,32 @@ func (s *SecretManager) SignTransactionEssence(txEssence types.HexEncodedString,
 
 	return methods.ParseResponse[types.Ed25519Signature](signedMessageStr, err)
 }
+
+// SignSecp256k1Ecdsa signs the keccak256 hash of the message with the secp256k1 key of the BIP44 chain, usually of coin type CoinTypeEther
+func (s *SecretManager) SignSecp256k1Ecdsa(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error) {
+	signedMessageStr, free, err := s.callMethod(methods.SignSecp256K1EcdsaMethod(methods.SignSecp256K1EcdsaMethodData{
+		Message: message,
+		Chain:   bip44Chain,
+	}))
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[types.Secp256k1EcdsaSignature](signedMessageStr, err)
+}
+
+// callMethod calls a method of the native secret manager, which is retained during the call
+func (s *SecretManager) callMethod(method any) ([]byte, func(), error) {
+	if s.closed.Load() {
+		return nil, func() {}, fmt.Errorf("%w: %s", ErrHandleClosed, HandleKindSecretManager)
+	}
+
+	release, err := s.sdk.acquireHandle(HandleKindSecretManager, s.handle)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	defer release()
+
+	return s.sdk.CallSecretManagerMethod(s.secretManagerPtr, method)
+}
@@ -0,0 +1,51 @@
+package wasp_wallet_sdk
+
+import (
+	"fmt"
+
+	"github.com/awnumar/memguard"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// Signer is a source of keys, to generate addresses and sign with them.
+// It's implemented by Wallet and SecretManager, and by secret managers that don't use the native library.
+type Signer interface {
+	// GenerateEd25519Addresses generates a range of addresses, an empty bech32Hrp defaults to the HRP of the coin type
+	GenerateEd25519Addresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) ([]string, error)
+
+	GenerateEd25519Address(addressIndex uint32, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) (string, error)
+
+	GenerateEvmAddresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, options *types.IGenerateAddressOptions) ([]string, error)
+
+	SignTransactionEssence(txEssence types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Ed25519Signature, error)
+
+	// SignSecp256k1Ecdsa signs the keccak256 hash of the message with the secp256k1 key of the BIP44 chain
+	SignSecp256k1Ecdsa(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error)
+
+	// GetLedgerStatus returns the status of the Ledger, other secret managers fail
+	GetLedgerStatus() (*types.LedgerNanoStatus, error)
+
+	// StoreMnemonic stores the mnemonic in a Stronghold snapshot, other secret managers fail
+	StoreMnemonic(mnemonic *memguard.Enclave) (bool, error)
+}
+
+var (
+	_ Signer = (*Wallet)(nil)
+	_ Signer = (*SecretManager)(nil)
+)
+
+// SecretManager returns the secret manager of the wallet.
+// The secret manager has to be closed; the native secret manager stays alive until both the wallet and the secret manager are closed.
+func (s *Wallet) SecretManager() (*SecretManager, error) {
+	handle := s.sdk.handles.lookup(HandleKindSecretManager, uintptr(s.secretManagerPtr))
+	if s.secretManagerPtr == 0 || handle == nil || s.closed.Load() {
+		return nil, fmt.Errorf("%w: %s", ErrHandleClosed, HandleKindSecretManager)
+	}
+
+	if err := s.sdk.handles.retain(handle); err != nil {
+		return nil, err
+	}
+
+	return newSecretManager(s.sdk, handle), nil
+}
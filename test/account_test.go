@@ -0,0 +1,25 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestAccountIdentifierJSON(t *testing.T) {
+	byIndex, err := json.Marshal(methods.GetAccountMethod(methods.GetAccountMethodData{
+		AccountID: types.AccountIndexIdentifier(0),
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"getAccount","data":{"accountId":0}}`, string(byIndex))
+
+	byAlias, err := json.Marshal(methods.GetAccountMethod(methods.GetAccountMethodData{
+		AccountID: types.AccountAliasIdentifier("alice"),
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"getAccount","data":{"accountId":"alice"}}`, string(byAlias))
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func newAliasOutputData(t *testing.T) types.OutputData {
+	var output types.OutputDataOutput
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": 4,
+		"amount": "52800",
+		"aliasId": "0x`+strings.Repeat("00", 32)+`",
+		"stateIndex": 0,
+		"foundryCounter": 0,
+		"unlockConditions": [
+			{"type": 4, "address": {"type": 0, "pubKeyHash": "0x01"}},
+			{"type": 5, "address": {"type": 0, "pubKeyHash": "0x02"}}
+		],
+		"features": [
+			{"type": 0, "address": {"type": 0, "pubKeyHash": "0x01"}},
+			{"type": 3, "tag": "0x01"}
+		]
+	}`), &output))
+
+	return types.OutputData{
+		OutputId: types.OutputId("0x" + strings.Repeat("cd", 32) + "0100"),
+		Output:   output,
+	}
+}
+
+func TestNewAlias(t *testing.T) {
+	outputData := newAliasOutputData(t)
+
+	alias, err := types.NewAlias(outputData)
+	require.NoError(t, err)
+
+	expectedId, err := types.ComputeOutputChainId(outputData.OutputId)
+	require.NoError(t, err)
+	require.Equal(t, expectedId, alias.AliasId)
+	require.Equal(t, types.HexEncodedString("0x01"), alias.StateController.PubKeyHash)
+	require.Equal(t, types.HexEncodedString("0x02"), alias.Governor.PubKeyHash)
+	require.Equal(t, uint32(0), alias.StateIndex)
+
+	outputData.Output["type"] = 6
+	_, err = types.NewAlias(outputData)
+	require.Error(t, err)
+}
+
+func TestAliasStateTransition(t *testing.T) {
+	outputData := newAliasOutputData(t)
+
+	next, err := types.NewAliasStateTransition(outputData, "0xcafe")
+	require.NoError(t, err)
+
+	alias, err := types.NewAlias(types.OutputData{OutputId: outputData.OutputId, Output: next})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), alias.StateIndex)
+	require.Equal(t, types.HexEncodedString("0xcafe"), alias.StateMetadata)
+	require.Equal(t, alias.AliasId, next["aliasId"])
+
+	// The original output is not modified
+	require.Nil(t, outputData.Output["stateMetadata"])
+}
+
+func TestAliasGovernanceTransition(t *testing.T) {
+	outputData := newAliasOutputData(t)
+	governor := &types.Address{Type: types.AddressTypeEd25519, PubKeyHash: "0x03"}
+	metadata := types.HexEncodedString("0xbeef")
+
+	next, err := types.NewAliasGovernanceTransition(outputData, nil, governor, &metadata)
+	require.NoError(t, err)
+
+	serialized, err := json.Marshal(next)
+	require.NoError(t, err)
+
+	var output types.Output
+	require.NoError(t, json.Unmarshal(serialized, &output))
+	require.Equal(t, uint32(0), output.StateIndex)
+	require.Equal(t, types.HexEncodedString("0x01"), output.UnlockCondition(types.UnlockConditionTypeStateControllerAddress).Address.PubKeyHash)
+	require.Equal(t, types.HexEncodedString("0x03"), output.UnlockCondition(types.UnlockConditionTypeGovernorAddress).Address.PubKeyHash)
+
+	// Features stay sorted by type
+	require.Len(t, output.Features, 3)
+	require.Equal(t, types.FeatureTypeMetadata, output.Features[1].Type)
+	require.Equal(t, metadata, output.Features[1].Data)
+
+	empty := types.HexEncodedString("")
+	next, err = types.NewAliasGovernanceTransition(outputData, nil, nil, &empty)
+	require.NoError(t, err)
+	require.Len(t, next["features"], 2)
+}
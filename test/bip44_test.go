@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestParseBip44Path(t *testing.T) {
+	chain, err := types.ParseBip44Path("m/44'/4219'/0'/0'/5'")
+	require.NoError(t, err)
+	require.Equal(t, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 5), chain)
+	require.Equal(t, "m/44'/4219'/0'/0'/5'", chain.String())
+
+	chain, err = types.ParseBip44Path("m/44h/60H/3/1/7'")
+	require.NoError(t, err)
+	require.Equal(t, wasp_wallet_sdk.BuildInternalBip44Chain(types.CoinTypeEther, 3, 7), chain)
+	require.True(t, chain.IsInternal())
+	require.Equal(t, "m/44'/60'/3'/1'/7'", chain.String())
+
+	chain, err = types.ParseBip44Path("m/44'/4218'/2147483647'/0'/0'")
+	require.NoError(t, err)
+	require.Equal(t, uint32(2147483647), chain.Account)
+}
+
+func TestParseBip44PathInvalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"m",
+		"44'/4219'/0'/0'/0'",
+		"m/44'/4219'/0'/0'",
+		"m/44'/4219'/0'/0'/0'/0'",
+		"m/43'/4219'/0'/0'/0'",
+		"m/44'/4219'/0'/2'/0'",
+		"m/44'/4219'/2147483648'/0'/0'",
+		"m/44'/4219'/0'/0'/4294967296'",
+		"m/44'/4219'/-1'/0'/0'",
+		"m/44'/4219'/+1'/0'/0'",
+		"m/44'/4219'/'/0'/0'",
+		"m/44'/smr'/0'/0'/0'",
+		"m/44'/4219'/0'/0'/5'''",
+		"m/44'/4219'/0'/0'/5hH'",
+		"m/44'/4219'/0'/0'/''",
+	} {
+		_, err := types.ParseBip44Path(path)
+		require.ErrorIs(t, err, types.ErrInvalidBip44Path, path)
+	}
+}
+
+func TestBip44ChainValidate(t *testing.T) {
+	require.NoError(t, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0).Validate())
+
+	chain := wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, types.Bip44HardenedOffset)
+	require.ErrorIs(t, chain.Validate(), types.ErrInvalidBip44Path)
+
+	chain = wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0)
+	chain.Change = 5
+	require.ErrorIs(t, chain.Validate(), types.ErrInvalidBip44Path)
+}
+
+func TestBip44ChainsFromOptions(t *testing.T) {
+	chains, err := types.Bip44ChainsFromOptions(types.IGenerateAddressesOptions{
+		AccountIndex: 1,
+		CoinType:     types.CoinTypeSMR,
+		Range:        types.NewRange(2, 5),
+		Options:      &types.IGenerateAddressOptions{Internal: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, chains, 3)
+
+	for i, chain := range chains {
+		require.Equal(t, wasp_wallet_sdk.BuildInternalBip44Chain(types.CoinTypeSMR, 1, uint32(i+2)), chain)
+	}
+
+	chains, err = types.Bip44ChainsFromOptions(types.IGenerateAddressesOptions{
+		CoinType: types.CoinTypeIOTA,
+		Range:    types.NewRange(0, 1),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []types.Bip44Chain{wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeIOTA, 0, 0)}, chains)
+
+	_, err = types.Bip44ChainsFromOptions(types.IGenerateAddressesOptions{Range: types.NewRange(5, 2)})
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestNewClaimableOutput(t *testing.T) {
+	var output types.OutputDataOutput
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": 3,
+		"amount": "1000000",
+		"nativeTokens": [{"id": "0x08ab", "amount": "0x64"}],
+		"unlockConditions": [
+			{"type": 0, "address": {"type": 0, "pubKeyHash": "0x01"}},
+			{"type": 1, "returnAddress": {"type": 8, "aliasId": "0x02"}, "amount": "45600"},
+			{"type": 2, "unixTime": 1700000000},
+			{"type": 3, "returnAddress": {"type": 8, "aliasId": "0x02"}, "unixTime": 1800000000}
+		],
+		"features": [
+			{"type": 0, "address": {"type": 8, "aliasId": "0x02"}}
+		]
+	}`), &output))
+
+	outputId := types.OutputId("0x" + strings.Repeat("ef", 32) + "0000")
+	claimable, err := types.NewClaimableOutput(types.OutputData{OutputId: outputId, Output: output})
+	require.NoError(t, err)
+
+	require.Equal(t, outputId, claimable.OutputId)
+	require.Equal(t, types.OutputTypeBasic, claimable.OutputType)
+	require.Equal(t, "1000000", claimable.Amount)
+	require.Len(t, claimable.NativeTokens, 1)
+	require.Equal(t, types.HexEncodedString("0x02"), claimable.Sender.AliasId)
+
+	require.NotNil(t, claimable.StorageDepositReturn)
+	require.Equal(t, "45600", claimable.StorageDepositReturn.Amount)
+	require.Equal(t, types.AddressTypeAlias, claimable.StorageDepositReturn.ReturnAddress.Type)
+
+	require.NotNil(t, claimable.Expiration)
+	require.Equal(t, uint32(1800000000), claimable.Expiration.UnixTime)
+
+	require.NotNil(t, claimable.Timelock)
+	require.Equal(t, uint32(1700000000), *claimable.Timelock)
+	require.Empty(t, claimable.NftId)
+}
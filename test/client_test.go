@@ -0,0 +1,29 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestPostBlockRawSerialization(t *testing.T) {
+	serialized, err := json.Marshal(methods.PostBlockRawMethod(methods.PostBlockRawMethodData{
+		BlockBytes: []byte{0x02, 0xff, 0x00},
+	}))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"postBlockRaw","data":{"blockBytes":[2,255,0]}}`, string(serialized))
+}
+
+func TestBlockMetadataReferenced(t *testing.T) {
+	var metadata types.BlockMetadata
+	require.NoError(t, json.Unmarshal([]byte(`{"blockId":"0x01","parents":[],"isSolid":true,"shouldPromote":false}`), &metadata))
+	require.False(t, metadata.IsReferenced())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"blockId":"0x01","parents":[],"isSolid":true,"referencedByMilestoneIndex":42,"ledgerInclusionState":"noTransaction"}`), &metadata))
+	require.True(t, metadata.IsReferenced())
+	require.Equal(t, types.LedgerInclusionStateNoTransaction, metadata.LedgerInclusionState)
+}
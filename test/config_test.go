@@ -0,0 +1,208 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/config"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadYAMLConfig(t *testing.T) {
+	t.Setenv("TEST_WALLET_PASSWORD", "hunter2")
+
+	path := writeConfig(t, "wallet.yaml", `
+network: shimmer
+storagePath: ./wallet-db
+clientOptions:
+  nodes: ["https://api.testnet.shimmer.network"]
+  ignoreNodeHealth: true
+secretManager:
+  type: stronghold
+  snapshotPath: ./wallet.stronghold
+  passwordSource: env:TEST_WALLET_PASSWORD
+logger:
+  name: wallet.log
+  levelFilter: debug
+`)
+
+	options, err := config.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, types.CoinTypeSMR, options.CoinType)
+	require.Equal(t, "./wallet-db", options.StoragePath)
+	require.Equal(t, []any{"https://api.testnet.shimmer.network"}, options.ClientOptions.Nodes)
+	require.True(t, options.ClientOptions.IgnoreNodeHealth)
+	require.Equal(t, types.StrongholdSecretManager{
+		Stronghold: types.StrongholdSecretManagerOptions{
+			Password:     "hunter2",
+			SnapshotPath: "./wallet.stronghold",
+		},
+	}, options.SecretManager)
+
+	cfg, err := config.LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "wallet.log", cfg.Logger.Name)
+}
+
+func TestLoadTOMLConfig(t *testing.T) {
+	path := writeConfig(t, "wallet.toml", `
+coinType = 4218
+storagePath = "./wallet-db"
+
+[clientOptions]
+nodes = ["http://localhost:14265"]
+
+[secretManager]
+type = "ledger"
+emulator = true
+`)
+
+	options, err := config.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, types.CoinTypeIOTA, options.CoinType)
+	require.Equal(t, &types.LedgerNanoSecretManager{LedgerNano: true}, options.SecretManager)
+}
+
+func TestConfigSecretFileSource(t *testing.T) {
+	credentials := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(credentials, "mnemonic"), []byte("word word word\n"), 0o600))
+	t.Setenv("TEST_CREDENTIALS_DIRECTORY", credentials)
+
+	cfg, err := config.Parse([]byte(`
+network: iota
+storagePath: db
+clientOptions: {nodes: ["http://localhost:14265"]}
+secretManager:
+  type: mnemonic
+  mnemonicSource: file:${TEST_CREDENTIALS_DIRECTORY}/mnemonic
+`), config.FormatYAML)
+	require.NoError(t, err)
+
+	options, err := cfg.WalletOptions()
+	require.NoError(t, err)
+	require.Equal(t, types.MnemonicSecretManager{Mnemonic: "word word word"}, options.SecretManager)
+
+	enclave, err := config.ResolveSecret(cfg.SecretManager.MnemonicSource)
+	require.NoError(t, err)
+	buffer, err := enclave.Open()
+	require.NoError(t, err)
+	require.Equal(t, "word word word", buffer.String())
+	buffer.Destroy()
+
+	_, err = config.ResolveSecret("env:TEST_UNSET_SECRET")
+	require.ErrorIs(t, err, config.ErrSecretUnavailable)
+}
+
+func TestConfigRejectsInlineSecrets(t *testing.T) {
+	_, err := config.Parse([]byte(`
+network: iota
+storagePath: db
+clientOptions: {nodes: ["http://localhost:14265"]}
+secretManager:
+  type: stronghold
+  snapshotPath: wallet.stronghold
+  password: hunter2
+`), config.FormatYAML)
+	require.ErrorIs(t, err, config.ErrInlineSecret)
+
+	_, err = config.Parse([]byte(`
+[secretManager]
+type = "mnemonic"
+mnemonic = "word word word"
+`), config.FormatTOML)
+	require.ErrorIs(t, err, config.ErrInlineSecret)
+}
+
+func TestConfigValidationErrors(t *testing.T) {
+	_, err := config.Parse([]byte(`
+network: iota
+storagePath: db
+unknownField: true
+secretManager: {type: ledger}
+`), config.FormatYAML)
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+
+	_, err = config.Parse([]byte(`
+coinType = 4219
+network = "iota"
+
+[secretManager]
+type = "stronghold"
+emulator = true
+passwordSource = "vault:wallet"
+`), config.FormatTOML)
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+
+	var validationError *config.ValidationError
+	require.ErrorAs(t, err, &validationError)
+
+	for _, field := range []string{
+		"network:",
+		"storagePath: required",
+		"clientOptions.nodes:",
+		"secretManager.snapshotPath: required",
+		"secretManager.emulator: not used",
+		"secretManager.passwordSource: unknown secret source",
+	} {
+		require.Contains(t, err.Error(), field)
+	}
+}
+
+func TestConfigEnvOverrides(t *testing.T) {
+	t.Setenv("WASP_WALLET_NODES", "http://a:14265, http://b:14265")
+	t.Setenv("WASP_WALLET_SECRET_MANAGER_TYPE", "ledger")
+	t.Setenv("WASP_WALLET_STORAGE_PATH", "/var/lib/wallet")
+
+	cfg, err := config.Parse([]byte(`
+network = "shimmer"
+storagePath = "db"
+
+[secretManager]
+type = "mnemonic"
+`), config.FormatTOML)
+	require.NoError(t, err)
+	require.Equal(t, config.SecretManagerLedger, cfg.SecretManager.Type)
+	require.Equal(t, "/var/lib/wallet", cfg.StoragePath)
+	require.Equal(t, []any{"http://a:14265", "http://b:14265"}, cfg.ClientOptions.Nodes)
+
+	t.Setenv("WASP_WALLET_LEDGER_EMULATOR", "maybe")
+	_, err = config.Parse([]byte(`network = "shimmer"`), config.FormatTOML)
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestConfigEnvOverridesSecretManagerType(t *testing.T) {
+	t.Setenv("WASP_WALLET_SECRET_MANAGER_TYPE", "mnemonic")
+	t.Setenv("WASP_WALLET_MNEMONIC_SOURCE", "env:WALLET_MNEMONIC")
+
+	// The Stronghold fields of the file don't apply to the mnemonic secret manager
+	cfg, err := config.Parse([]byte(`
+network = "shimmer"
+storagePath = "db"
+
+[clientOptions]
+nodes = ["http://localhost:14265"]
+
+[secretManager]
+type = "stronghold"
+snapshotPath = "wallet.stronghold"
+passwordSource = "env:WALLET_PASSWORD"
+`), config.FormatTOML)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, config.SecretManagerConfig{Type: config.SecretManagerMnemonic, MnemonicSource: "env:WALLET_MNEMONIC"}, cfg.SecretManager)
+}
+
+func TestConfigFormatByExtension(t *testing.T) {
+	_, err := config.LoadConfig(writeConfig(t, "wallet.json", `{}`))
+	require.Error(t, err)
+}
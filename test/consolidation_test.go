@@ -0,0 +1,65 @@
+package test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func newBasicOutputData(index int, unlockConditions ...any) types.OutputData {
+	return types.OutputData{
+		OutputId: types.OutputId(fmt.Sprintf("0x%064x0000", index)),
+		Output: types.OutputDataOutput{
+			"type":             3,
+			"amount":           "50000",
+			"unlockConditions": unlockConditions,
+		},
+	}
+}
+
+func TestConsolidationPreview(t *testing.T) {
+	addressUnlock := map[string]any{"type": 0, "address": map[string]any{"type": 0, "pubKeyHash": "0x01"}}
+	timelockUnlock := map[string]any{"type": 2, "unixTime": 1}
+
+	outputs := []types.OutputData{
+		newBasicOutputData(1, addressUnlock),
+		newBasicOutputData(2, addressUnlock),
+		newBasicOutputData(3, addressUnlock),
+		newBasicOutputData(4, addressUnlock, timelockUnlock),
+		newBasicOutputData(5, addressUnlock),
+	}
+	locked := []types.OutputId{outputs[4].OutputId}
+
+	require.False(t, types.IsConsolidatable(outputs[3]))
+
+	// Below the threshold nothing is merged
+	preview := types.NewConsolidationPreview(types.ConsolidationParams{OutputThreshold: 4}, outputs, locked, 5, big.NewInt(250000))
+	require.Empty(t, preview.OutputIds)
+	require.Zero(t, preview.FreedStorageDeposit.Sign())
+
+	preview = types.NewConsolidationPreview(types.ConsolidationParams{OutputThreshold: 3}, outputs, locked, 5, big.NewInt(250000))
+	require.Equal(t, []types.OutputId{outputs[0].OutputId, outputs[1].OutputId, outputs[2].OutputId}, preview.OutputIds)
+	require.Equal(t, int64(100000), preview.FreedStorageDeposit.Int64())
+
+	preview = types.NewConsolidationPreview(types.ConsolidationParams{Force: true}, outputs, locked, 5, big.NewInt(250000))
+	require.Len(t, preview.OutputIds, 3)
+}
+
+func TestOutputConsolidationThreshold(t *testing.T) {
+	require.Equal(t, uint32(types.LedgerOutputConsolidationThreshold), types.OutputConsolidationThreshold(types.LedgerNanoSecretManager{}))
+	require.Equal(t, uint32(types.LedgerOutputConsolidationThreshold), types.OutputConsolidationThreshold(&types.LedgerNanoSecretManager{LedgerNano: true}))
+	require.Equal(t, uint32(types.DefaultOutputConsolidationThreshold), types.OutputConsolidationThreshold(types.MnemonicSecretManager{}))
+	require.Equal(t, uint32(types.DefaultOutputConsolidationThreshold), types.OutputConsolidationThreshold(types.StrongholdSecretManager{}))
+}
+
+func TestWalletEventType(t *testing.T) {
+	event := types.WalletEvent{AccountIndex: 1, Event: []byte(`{"type":0}`)}
+
+	eventType, err := event.Type()
+	require.NoError(t, err)
+	require.Equal(t, types.WalletEventTypeConsolidationRequired, eventType)
+}
@@ -1,13 +1,15 @@
 package test
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 
 	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/lib_loader"
 	"github.com/iotaledger/wasp-wallet-sdk/types"
 
 	"github.com/stretchr/testify/require"
@@ -24,6 +26,10 @@ func FromEnv(envVar string, defaultValue string) string {
 var (
 	ShimmerNetworkAPI     = FromEnv("TEST_NETWORK_API", "https://api.shimmer.network")
 	UseLedgerSimulator, _ = strconv.ParseBool(FromEnv("TEST_USE_LEDGER_SIMULATOR", "false"))
+
+	// ReplayMode "record" records the native calls of each test into ReplayDir, "replay" serves them without the native library
+	ReplayMode = FromEnv("TEST_REPLAY_MODE", "")
+	ReplayDir  = FromEnv("TEST_REPLAY_DIR", "testdata/replay")
 )
 
 // Mnemonic chosen by fair dice roll.
@@ -47,7 +53,7 @@ func getIOTASDKLibraryPath() string {
 		return wd + "/../../iota-sdk-native-bindings/target/debug/libiota_sdk.so"
 
 	case "windows":
-		return wd + "/../../iota-sdk-native-bindings/target/release/libiota_sdk.dll"
+		return wd + "/../../iota-sdk-native-bindings/target/release/iota_sdk.dll"
 
 	default:
 		return ""
@@ -57,16 +63,21 @@ func getIOTASDKLibraryPath() string {
 var sdk *wasp_wallet_sdk.IOTASDK
 
 func GetOrInitTest(t *testing.T) *wasp_wallet_sdk.IOTASDK {
+	switch ReplayMode {
+	case "record":
+		return recordTest(t)
+	case "replay":
+		return replayTest(t)
+	}
+
 	var err error
 	if sdk != nil {
 		return sdk
 	}
 
-	sdk, err = wasp_wallet_sdk.NewIotaSDK(getIOTASDKLibraryPath())
+	sdk, err = newTestSDK()
 	require.NoError(t, err)
 
-	fmt.Println(getIOTASDKLibraryPath())
-
 	success, err := sdk.InitLogger(types.ILoggerConfig{
 		LevelFilter: types.LevelFilterTrace,
 	})
@@ -75,3 +86,46 @@ func GetOrInitTest(t *testing.T) *wasp_wallet_sdk.IOTASDK {
 
 	return sdk
 }
+
+// newTestSDK loads the library of IOTA_SDK_LIB, or the one built next to this repo
+func newTestSDK() (*wasp_wallet_sdk.IOTASDK, error) {
+	if os.Getenv(lib_loader.LibraryPathEnvVar) != "" {
+		return wasp_wallet_sdk.NewIotaSDKFromEnv()
+	}
+
+	return wasp_wallet_sdk.NewIotaSDK(getIOTASDKLibraryPath())
+}
+
+func replayPath(t *testing.T) string {
+	return filepath.Join(ReplayDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+}
+
+// recordTest returns a separate SDK for the test, its calls are saved if the test passes
+func recordTest(t *testing.T) *wasp_wallet_sdk.IOTASDK {
+	recordingSDK, err := newTestSDK()
+	require.NoError(t, err)
+
+	recording := recordingSDK.StartRecording()
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+
+		require.NoError(t, os.MkdirAll(ReplayDir, 0o755))
+		require.NoError(t, recording.Save(replayPath(t)))
+	})
+
+	return recordingSDK
+}
+
+func replayTest(t *testing.T) *wasp_wallet_sdk.IOTASDK {
+	path := replayPath(t)
+	if _, err := os.Stat(path); err != nil {
+		t.Skipf("no recording %s, record it with TEST_REPLAY_MODE=record", path)
+	}
+
+	replaySDK, err := wasp_wallet_sdk.NewReplaySDK(path)
+	require.NoError(t, err)
+
+	return replaySDK
+}
@@ -0,0 +1,256 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/hdwallet"
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/lib_loader"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// Test vector 1 of SLIP-10 and BIP32
+var derivationTestSeed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+const H = hdwallet.Hardened
+
+func TestDeriveEd25519(t *testing.T) {
+	vectors := []struct {
+		path       []uint32
+		privateKey string
+		publicKey  string
+	}{
+		{nil, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{[]uint32{0 | H}, "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{[]uint32{0 | H, 1 | H}, "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2", "1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+		{[]uint32{0 | H, 1 | H, 2 | H}, "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9", "ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1"},
+		{[]uint32{0 | H, 1 | H, 2 | H, 2 | H}, "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662", "8abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c"},
+		{[]uint32{0 | H, 1 | H, 2 | H, 2 | H, 1000000000 | H}, "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793", "3c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a"},
+	}
+
+	for _, vector := range vectors {
+		privateKey, err := hdwallet.DeriveEd25519(derivationTestSeed, vector.path)
+		require.NoError(t, err)
+		require.Equal(t, vector.privateKey, hex.EncodeToString(privateKey))
+		require.Equal(t, vector.publicKey, hex.EncodeToString(ed25519.NewKeyFromSeed(privateKey).Public().(ed25519.PublicKey)))
+	}
+
+	_, err := hdwallet.DeriveEd25519(derivationTestSeed, []uint32{0})
+	require.ErrorIs(t, err, hdwallet.ErrNonHardenedIndex)
+}
+
+func TestDeriveSecp256k1(t *testing.T) {
+	vectors := []struct {
+		path       []uint32
+		privateKey string
+	}{
+		{nil, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{[]uint32{0 | H}, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{[]uint32{0 | H, 1}, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{[]uint32{0 | H, 1, 2 | H}, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{[]uint32{0 | H, 1, 2 | H, 2}, "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{[]uint32{0 | H, 1, 2 | H, 2, 1000000000}, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, vector := range vectors {
+		privateKey, err := hdwallet.DeriveSecp256k1(derivationTestSeed, vector.path)
+		require.NoError(t, err)
+		require.Equal(t, vector.privateKey, hex.EncodeToString(privateKey))
+	}
+}
+
+func TestHDWalletMnemonic(t *testing.T) {
+	mnemonic := strings.TrimSpace(strings.Repeat("abandon ", 11)) + " about"
+
+	// BIP39 test vector, with the passphrase of the reference implementation
+	seed := hdwallet.SeedFromMnemonic([]byte(mnemonic), []byte("TREZOR"))
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	secretManager, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(mnemonic)))
+	require.NoError(t, err)
+
+	// The well-known first Ethereum address of the mnemonic, m/44'/60'/0'/0/0
+	evmAddresses, err := secretManager.GenerateEvmAddresses(types.NewRange(0, 2), 0, "", nil)
+	require.NoError(t, err)
+	require.Len(t, evmAddresses, 2)
+	require.Equal(t, "0x9858effd232b4033e47d90003d41ec34ecaeda94", evmAddresses[0])
+
+	ed25519Addresses, err := secretManager.GenerateEd25519Addresses(types.NewRange(0, 2), 0, "", types.CoinTypeSMR, nil)
+	require.NoError(t, err)
+	require.Len(t, ed25519Addresses, 2)
+	require.True(t, strings.HasPrefix(ed25519Addresses[0], "smr1q"))
+
+	ed25519Address, err := secretManager.GenerateEd25519Address(1, 0, "rms", types.CoinTypeSMR, nil)
+	require.NoError(t, err)
+	_, expected, err := isc.AddressFromBech32(ed25519Addresses[1])
+	require.NoError(t, err)
+	hrp, address, err := isc.AddressFromBech32(ed25519Address)
+	require.NoError(t, err)
+	require.Equal(t, "rms", hrp)
+	require.Equal(t, expected, address)
+
+	signature, err := secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.NoError(t, err)
+	publicKey, err := types.HexEncodedString(signature.PublicKey).Bytes()
+	require.NoError(t, err)
+	signatureBytes, err := types.HexEncodedString(signature.Signature).Bytes()
+	require.NoError(t, err)
+	message, err := types.HexEncodedString(SignMessageFromEssenceHex).Bytes()
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(publicKey, message, signatureBytes))
+
+	// Ledger and Stronghold methods aren't supported
+	_, err = secretManager.GetLedgerStatus()
+	require.ErrorIs(t, err, hdwallet.ErrUnsupported)
+	_, err = secretManager.StoreMnemonic(memguard.NewEnclave([]byte(Mnemonic)))
+	require.ErrorIs(t, err, hdwallet.ErrUnsupported)
+
+	secretManager.Destroy()
+	_, err = secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.ErrorIs(t, err, hdwallet.ErrSecretManagerClosed)
+}
+
+// Secrets of the address tests of iota-sdk, the native secret managers generate the addresses of nativeVectors from them
+const (
+	iotaSDKTestSeed     = "256a818b2aac458941f7274985a410e57fb750f3a3a67969ece5bd9ae7eef5b2"
+	iotaSDKTestMnemonic = "endorse answer radar about source reunion marriage tag sausage weekend frost daring base attack because joke dream slender leisure group reason prepare broken river"
+)
+
+type nativeVector struct {
+	mnemonic  bool
+	chain     types.Bip44Chain
+	bech32Hrp string
+	address   string
+}
+
+// nativeVectors are addresses asserted by the tests of iota-sdk, for a secret manager of iotaSDKTestSeed or iotaSDKTestMnemonic
+var nativeVectors = []nativeVector{
+	{mnemonic: false, chain: types.NewBip44Chain(types.CoinTypeIOTA, 0, 0, true), bech32Hrp: "atoi", address: "atoi1qprxpfvaz2peggq6f8k9cj8zfsxuw69e4nszjyv5kuf8yt70t2847shpjak"},
+	{mnemonic: true, chain: types.NewBip44Chain(types.CoinTypeSMR, 0, 1, false), bech32Hrp: "rms", address: "rms1qzzk86qv30l4e85ljtccxa0ruy8y7u8zn2dle3g8dv2tl2m4cu227a7n2wj"},
+	{mnemonic: true, chain: types.NewBip44Chain(types.CoinTypeEther, 0, 0, false), address: "0xcaefde2b487ded55688765964320ff390cd87828"},
+}
+
+// checkNativeVector compares the address of the chain with the vector, and checks that the signature of the chain is made by the key of the address.
+// Ed25519 and RFC 6979 secp256k1 signatures are deterministic, so a valid signature of that key is the native one.
+func checkNativeVector(t *testing.T, secretManager wasp_wallet_sdk.Signer, vector nativeVector) {
+	addressRange := types.NewRange(vector.chain.AddressIndex, vector.chain.AddressIndex+1)
+	options := &types.IGenerateAddressOptions{Internal: vector.chain.Change == types.Bip44ChangeInternal}
+
+	if types.CoinType(vector.chain.CoinType) == types.CoinTypeEther {
+		addresses, err := secretManager.GenerateEvmAddresses(addressRange, vector.chain.Account, "", options)
+		require.NoError(t, err)
+		require.Equal(t, []string{vector.address}, addresses)
+
+		// The sender is recovered from the signature
+		signed, err := (&isc.EVMTransaction{EVMChainID: 1, GasPrice: big.NewInt(0)}).Sign(secretManager, vector.chain)
+		require.NoError(t, err)
+		require.True(t, strings.EqualFold(vector.address, signed.Sender().String()))
+
+		return
+	}
+
+	addresses, err := secretManager.GenerateEd25519Addresses(addressRange, vector.chain.Account, vector.bech32Hrp, types.CoinType(vector.chain.CoinType), options)
+	require.NoError(t, err)
+	require.Equal(t, []string{vector.address}, addresses)
+
+	signature, err := secretManager.SignTransactionEssence(SignMessageFromEssenceHex, vector.chain)
+	require.NoError(t, err)
+	publicKey, err := types.HexEncodedString(signature.PublicKey).Bytes()
+	require.NoError(t, err)
+	signatureBytes, err := types.HexEncodedString(signature.Signature).Bytes()
+	require.NoError(t, err)
+	message, err := types.HexEncodedString(SignMessageFromEssenceHex).Bytes()
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(publicKey, message, signatureBytes))
+
+	pubKeyHash := blake2b.Sum256(publicKey)
+	address, err := isc.AddressToBech32(vector.bech32Hrp, types.Address{
+		Type:       types.AddressTypeEd25519,
+		PubKeyHash: types.NewHexEncodedString(pubKeyHash[:]),
+	})
+	require.NoError(t, err)
+	require.Equal(t, vector.address, address)
+}
+
+func TestHDWalletNativeVectors(t *testing.T) {
+	seed, err := hex.DecodeString(iotaSDKTestSeed)
+	require.NoError(t, err)
+	seedSecretManager, err := hdwallet.NewSeedSecretManager(memguard.NewEnclave(seed))
+	require.NoError(t, err)
+	defer seedSecretManager.Destroy()
+
+	mnemonicSecretManager, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(iotaSDKTestMnemonic)))
+	require.NoError(t, err)
+	defer mnemonicSecretManager.Destroy()
+
+	for _, vector := range nativeVectors {
+		if vector.mnemonic {
+			checkNativeVector(t, mnemonicSecretManager, vector)
+		} else {
+			checkNativeVector(t, seedSecretManager, vector)
+		}
+	}
+}
+
+// TestHDWalletMatchesNative compares the addresses and signatures with the native secret manager, it's skipped without the native library
+func TestHDWalletMatchesNative(t *testing.T) {
+	if ReplayMode == "" && os.Getenv(lib_loader.LibraryPathEnvVar) == "" {
+		if _, err := os.Stat(getIOTASDKLibraryPath()); err != nil {
+			t.Skipf("native library not found: %v", err)
+		}
+	}
+
+	sdk := GetOrInitTest(t)
+
+	nativeSecretManager, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(iotaSDKTestMnemonic)))
+	require.NoError(t, err)
+	defer nativeSecretManager.Destroy()
+
+	for _, vector := range nativeVectors {
+		if vector.mnemonic {
+			checkNativeVector(t, nativeSecretManager, vector)
+		}
+	}
+
+	secretManager, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(iotaSDKTestMnemonic)))
+	require.NoError(t, err)
+	defer secretManager.Destroy()
+
+	for _, options := range []*types.IGenerateAddressOptions{nil, {Internal: true}} {
+		expected, err := nativeSecretManager.GenerateEd25519Addresses(types.NewRange(0, 5), 1, "smr", types.CoinTypeSMR, options)
+		require.NoError(t, err)
+		actual, err := secretManager.GenerateEd25519Addresses(types.NewRange(0, 5), 1, "smr", types.CoinTypeSMR, options)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		expected, err = nativeSecretManager.GenerateEvmAddresses(types.NewRange(0, 5), 1, "", options)
+		require.NoError(t, err)
+		actual, err = secretManager.GenerateEvmAddresses(types.NewRange(0, 5), 1, "", options)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	chain := wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 3)
+	expectedSignature, err := nativeSecretManager.SignTransactionEssence(SignMessageFromEssenceHex, chain)
+	require.NoError(t, err)
+	actualSignature, err := secretManager.SignTransactionEssence(SignMessageFromEssenceHex, chain)
+	require.NoError(t, err)
+	require.Equal(t, expectedSignature, actualSignature)
+
+	chain = wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeEther, 0, 3)
+	expectedEcdsa, err := nativeSecretManager.SignSecp256k1Ecdsa(SignMessageFromEssenceHex, chain)
+	require.NoError(t, err)
+	actualEcdsa, err := secretManager.SignSecp256k1Ecdsa(SignMessageFromEssenceHex, chain)
+	require.NoError(t, err)
+	require.Equal(t, expectedEcdsa, actualEcdsa)
+}
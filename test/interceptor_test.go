@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+)
+
+// newInterceptedSDK replays the calls, so the interceptors are tested without the native library
+func newInterceptedSDK(t *testing.T, calls string) *wasp_wallet_sdk.IOTASDK {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{"calls": [`+calls+`]}`))
+	require.NoError(t, err)
+	t.Cleanup(sdk.Destroy)
+
+	return sdk
+}
+
+const (
+	verifyMnemonicCall       = `{"call": "call_utils_method", "request": {"name": "verifyMnemonic", "data": {"mnemonic": "[REDACTED]"}}, "response": {"type": "ok"}}`
+	verifyMnemonicFailedCall = `{"call": "call_utils_method", "request": {"name": "verifyMnemonic", "data": {"mnemonic": "[REDACTED]"}}, "response": {"type": "error", "payload": {"type": "mnemonic", "error": "invalid mnemonic"}}}`
+)
+
+func TestInterceptorRedactsPayload(t *testing.T) {
+	sdk := newInterceptedSDK(t, verifyMnemonicCall)
+
+	type call struct {
+		domain  wasp_wallet_sdk.Domain
+		method  string
+		payload string
+	}
+	var calls []call
+
+	sdk.Use(func(ctx context.Context, domain wasp_wallet_sdk.Domain, method string, next wasp_wallet_sdk.Next) error {
+		payload, err := wasp_wallet_sdk.RedactedPayload(ctx)
+		require.NoError(t, err)
+
+		calls = append(calls, call{domain: domain, method: method, payload: string(payload)})
+
+		return next(ctx)
+	})
+
+	require.NoError(t, sdk.Utils().VerifyMnemonic(memguard.NewEnclave([]byte(Mnemonic))))
+
+	require.Len(t, calls, 1)
+	require.Equal(t, wasp_wallet_sdk.DomainUtils, calls[0].domain)
+	require.Equal(t, "verifyMnemonic", calls[0].method)
+	require.JSONEq(t, `{"name":"verifyMnemonic","data":{"mnemonic":"[REDACTED]"}}`, calls[0].payload)
+}
+
+func TestInterceptorOrderAndRetry(t *testing.T) {
+	sdk := newInterceptedSDK(t, strings.Repeat(verifyMnemonicFailedCall+",", 4)+verifyMnemonicCall)
+
+	var order []string
+	sdk.Use(
+		func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+			order = append(order, "outer")
+			return next(ctx)
+		},
+		wasp_wallet_sdk.NewRetryInterceptor(3, time.Millisecond, func(_ wasp_wallet_sdk.Domain, _ string, err error) bool {
+			var methodErr *wasp_wallet_sdk.MethodError
+			return errors.As(err, &methodErr)
+		}),
+		func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+			order = append(order, "inner")
+			return next(ctx)
+		},
+	)
+
+	// The error response is retried, and still returned to the caller after the last attempt
+	err := sdk.Utils().VerifyMnemonic(memguard.NewEnclave([]byte("not a mnemonic")))
+	require.ErrorContains(t, err, "invalid mnemonic")
+	require.Equal(t, []string{"outer", "inner", "inner", "inner"}, order)
+
+	// A successful attempt after a failed one stops the retries
+	order = nil
+	require.NoError(t, sdk.Utils().VerifyMnemonic(memguard.NewEnclave([]byte(Mnemonic))))
+	require.Equal(t, []string{"outer", "inner", "inner"}, order)
+}
+
+func TestInterceptorRedactsErrors(t *testing.T) {
+	// Native errors may echo the mnemonic, as error response and as last error
+	sdk := newInterceptedSDK(t, fmt.Sprintf(`
+{"call": "call_utils_method", "request": {"name": "verifyMnemonic", "data": {"mnemonic": "[REDACTED]"}}, "response": {"type": "error", "payload": {"type": "mnemonic", "error": "invalid mnemonic: %[1]s"}}},
+{"call": "call_utils_method", "request": {"name": "verifyMnemonic", "data": {"mnemonic": "[REDACTED]"}}, "error": "invalid mnemonic: %[1]s"}`, Mnemonic))
+
+	var errs []error
+	sdk.Use(func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+		err := next(ctx)
+		errs = append(errs, err)
+
+		return err
+	})
+
+	for i := 0; i < 2; i++ {
+		require.Error(t, sdk.Utils().VerifyMnemonic(memguard.NewEnclave([]byte(Mnemonic))))
+	}
+
+	require.Len(t, errs, 2)
+
+	var methodErr *wasp_wallet_sdk.MethodError
+	require.ErrorAs(t, errs[0], &methodErr)
+	require.Equal(t, "mnemonic", methodErr.Type)
+	require.Equal(t, "invalid mnemonic: [REDACTED]", methodErr.Message)
+	require.EqualError(t, errs[1], "invalid mnemonic: [REDACTED]")
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	// Nothing is recorded, a call reaching the library would fail with ErrReplayMismatch
+	sdk := newInterceptedSDK(t, "")
+
+	errRateLimited := errors.New("rate limited")
+	sdk.Use(func(context.Context, wasp_wallet_sdk.Domain, string, wasp_wallet_sdk.Next) error {
+		return errRateLimited
+	})
+
+	_, err := sdk.Utils().GenerateMnemonic()
+	require.ErrorIs(t, err, errRateLimited)
+}
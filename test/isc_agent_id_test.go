@@ -0,0 +1,207 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+const testChainAliasId = "0xefdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"
+
+func testChainID(t *testing.T) isc.ChainID {
+	chainID, err := isc.ChainIDFromAliasId(testChainAliasId)
+	require.NoError(t, err)
+
+	return chainID
+}
+
+func TestCoreContractHnames(t *testing.T) {
+	require.Equal(t, "07cb02c1", isc.HnameEVM.String())
+	require.Equal(t, "cebf5908", isc.HnameRoot.String())
+}
+
+func TestChainIDFromString(t *testing.T) {
+	chainID := testChainID(t)
+
+	hrp, parsed, err := isc.ChainIDFromString(chainID.Bech32("smr"))
+	require.NoError(t, err)
+	require.Equal(t, "smr", hrp)
+	require.Equal(t, chainID, parsed)
+
+	hrp, parsed, err = isc.ChainIDFromString(testChainAliasId)
+	require.NoError(t, err)
+	require.Empty(t, hrp)
+	require.Equal(t, chainID, parsed)
+
+	// An Ed25519 address is not a chain ID
+	_, _, err = isc.ChainIDFromString("iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx")
+	require.Error(t, err)
+}
+
+func TestEthereumAddressChecksum(t *testing.T) {
+	// Vectors from EIP-55
+	for _, vector := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		address, err := isc.EthereumAddressFromHex(strings.ToLower(vector))
+		require.NoError(t, err)
+		require.Equal(t, vector, address.String())
+	}
+
+	_, err := isc.EthereumAddressFromHex("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA")
+	require.Error(t, err)
+}
+
+func TestAddressAgentID(t *testing.T) {
+	const bech32Address = "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx"
+
+	agentID, err := isc.AgentIDFromBech32Address(bech32Address)
+	require.NoError(t, err)
+	require.Equal(t, isc.AgentIDKindAddress, agentID.Kind())
+	require.Equal(t, types.AddressTypeEd25519, agentID.Address().Type)
+	require.Equal(t, bech32Address, agentID.Bech32("iota"))
+
+	expected := append([]byte{byte(isc.AgentIDKindAddress), byte(types.AddressTypeEd25519)}, hexBytes(t, testChainAliasId)...)
+	require.Equal(t, expected, agentID.Bytes())
+
+	requireAgentIDRoundTrip(t, "iota", agentID)
+}
+
+func TestContractAgentID(t *testing.T) {
+	chainID := testChainID(t)
+	agentID := isc.NewContractAgentID(chainID, isc.HnameAccounts)
+
+	require.Equal(t, "3c4b5e02@"+chainID.Bech32("smr"), agentID.Bech32("smr"))
+
+	expected := append([]byte{byte(isc.AgentIDKindContract)}, chainID[:]...)
+	expected = append(expected, 0x02, 0x5e, 0x4b, 0x3c)
+	require.Equal(t, expected, agentID.Bytes())
+
+	requireAgentIDRoundTrip(t, "smr", agentID)
+}
+
+func TestEthereumAddressAgentID(t *testing.T) {
+	chainID := testChainID(t)
+
+	// GenerateEvmAddresses returns lowercase hex addresses
+	agentID, err := isc.AgentIDFromEvmAddress(chainID, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	require.Equal(t, isc.AgentIDKindEthereumAddress, agentID.Kind())
+	require.Equal(t, chainID, agentID.ChainID())
+	require.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@"+chainID.Bech32("rms"), agentID.Bech32("rms"))
+
+	expected := append([]byte{byte(isc.AgentIDKindEthereumAddress)}, chainID[:]...)
+	expected = append(expected, hexBytes(t, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")...)
+	require.Equal(t, expected, agentID.Bytes())
+
+	requireAgentIDRoundTrip(t, "rms", agentID)
+}
+
+// Chain IDs of the ShimmerEVM and IOTA EVM chains operated with Wasp, the EVM address is the ISC magic contract of Wasp
+const (
+	shimmerEVMChainID        = "smr1prxvwqvwf7nru5q5xvh5thwg54zsm2y4wfnk6yk56hj3exxkg92mx20wl3s"
+	shimmerEVMTestnetChainID = "rms1ppp00k5mmd2m8my8ukkp58nd3rskw6rx8l09aj35984k74uuc5u2cywn3ex"
+	iotaEVMChainID           = "iota1pzt3mstq6khgc3tl0mwuzk3eqddkryqnpdxmk4nr25re2466uxwm28qqxu5"
+	iscMagicAddress          = "0x1074000000000000000000000000000000000000"
+)
+
+func TestWaspAgentIDVectors(t *testing.T) {
+	// Wasp encodes agent IDs as their kind followed by the address, or by the chain ID and the little endian hname or EVM address
+	for _, vector := range []struct {
+		agentID string
+		hrp     string
+		bytes   string
+	}{
+		{
+			agentID: "rms1qzzk86qv30l4e85ljtccxa0ruy8y7u8zn2dle3g8dv2tl2m4cu227a7n2wj",
+			hrp:     "rms",
+			bytes:   "0x01" + "00" + "8563e80c8bff5c9e9f92f18375e3e10e4f70e29a9bfcc5076b14bfab75c714af",
+		},
+		{
+			agentID: "3c4b5e02@" + shimmerEVMChainID,
+			hrp:     "smr",
+			bytes:   "0x02" + "ccc7018e4fa63e5014332f45ddc8a5450da89572676d12d4d5e51c98d64155b3" + "025e4b3c",
+		},
+		{
+			agentID: "07cb02c1@" + shimmerEVMTestnetChainID,
+			hrp:     "rms",
+			bytes:   "0x02" + "42f7da9bdb55b3ec87e5ac1a1e6d88e16768663fde5eca3429eb6f579cc538ac" + "c102cb07",
+		},
+		{
+			agentID: iscMagicAddress + "@" + iotaEVMChainID,
+			hrp:     "iota",
+			bytes:   "0x03" + "971dc160d5ae8c457f7eddc15a39035b6190130b4dbb5663550795575ae19db5" + "1074000000000000000000000000000000000000",
+		},
+		{
+			agentID: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@" + shimmerEVMChainID,
+			hrp:     "smr",
+			bytes:   "0x03" + "ccc7018e4fa63e5014332f45ddc8a5450da89572676d12d4d5e51c98d64155b3" + "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+	} {
+		hrp, agentID, err := isc.AgentIDFromString(vector.agentID)
+		require.NoError(t, err, vector.agentID)
+		require.Equal(t, vector.hrp, hrp)
+		require.Equal(t, vector.agentID, agentID.Bech32(hrp))
+		require.Equal(t, hexBytes(t, vector.bytes), agentID.Bytes(), vector.agentID)
+
+		decoded, err := isc.AgentIDFromBytes(hexBytes(t, vector.bytes))
+		require.NoError(t, err)
+		require.True(t, isc.AgentIDsEqual(agentID, decoded))
+	}
+}
+
+func TestNilAgentID(t *testing.T) {
+	agentID := &isc.NilAgentID{}
+	require.Equal(t, []byte{0}, agentID.Bytes())
+	require.Equal(t, "-", agentID.Bech32("iota"))
+
+	requireAgentIDRoundTrip(t, "", agentID)
+}
+
+func TestInvalidAgentIDs(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"iota1invalid",
+		"3c4b5e02@iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx",
+		"zzzz@" + testChainID(t).Bech32("iota"),
+		"0x1234@" + testChainID(t).Bech32("iota"),
+	} {
+		_, _, err := isc.AgentIDFromString(s)
+		require.ErrorIs(t, err, isc.ErrInvalidAgentID, s)
+	}
+
+	for _, data := range [][]byte{
+		nil,
+		{0x09},
+		{byte(isc.AgentIDKindContract), 0x01},
+		{byte(isc.AgentIDKindNil), 0x00},
+	} {
+		_, err := isc.AgentIDFromBytes(data)
+		require.ErrorIs(t, err, isc.ErrInvalidAgentID)
+	}
+}
+
+func requireAgentIDRoundTrip(t *testing.T, hrp string, agentID isc.AgentID) {
+	parsedHrp, parsed, err := isc.AgentIDFromString(agentID.Bech32(hrp))
+	require.NoError(t, err)
+	require.Equal(t, hrp, parsedHrp)
+	require.True(t, isc.AgentIDsEqual(agentID, parsed))
+
+	decoded, err := isc.AgentIDFromBytes(agentID.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, agentID, decoded)
+}
+
+func hexBytes(t *testing.T, s string) []byte {
+	data, err := types.HexEncodedString(s).Bytes()
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type secp256k1TestSigner struct {
+	privateKey *secp256k1.PrivateKey
+}
+
+func (s *secp256k1TestSigner) SignSecp256k1Ecdsa(message types.HexEncodedString, _ types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error) {
+	data, err := message.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+
+	// The compact signature is the recovery code followed by r || s
+	compact := ecdsa.SignCompact(s.privateKey, hash.Sum(nil), false)
+
+	return &types.Secp256k1EcdsaSignature{
+		PublicKey: string(types.NewHexEncodedString(s.privateKey.PubKey().SerializeCompressed())),
+		Signature: string(types.NewHexEncodedString(append(compact[1:], compact[0]-27))),
+	}, nil
+}
+
+// The example transaction of EIP-155
+func TestEVMTransactionEIP155(t *testing.T) {
+	var to isc.EthereumAddress
+	copy(to[:], hexBytes(t, "0x"+strings.Repeat("35", 20)))
+
+	transaction := &isc.EVMTransaction{
+		EVMChainID: 1,
+		Nonce:      9,
+		GasPrice:   big.NewInt(20_000_000_000),
+		Gas:        21000,
+		To:         to,
+		Value:      new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+	}
+	require.Equal(t, "ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080", hex.EncodeToString(transaction.SigningBytes()))
+
+	signer := &secp256k1TestSigner{privateKey: secp256k1.PrivKeyFromBytes(hexBytes(t, "0x"+strings.Repeat("46", 32)))}
+	signed, err := transaction.Sign(signer, types.Bip44Chain{})
+	require.NoError(t, err)
+	require.Equal(t, "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", hex.EncodeToString(signed.Bytes()))
+	require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", signed.Sender().String())
+
+	_, err = transaction.WithSignature(make([]byte, 64))
+	require.ErrorIs(t, err, isc.ErrInvalidSignature)
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+type ed25519TestSigner struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (s *ed25519TestSigner) SignTransactionEssence(message types.HexEncodedString, _ types.Bip44Chain) (*types.Ed25519Signature, error) {
+	data, err := message.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Ed25519Signature{
+		PublicKey: string(types.NewHexEncodedString(s.privateKey.Public().(ed25519.PublicKey))),
+		Signature: string(types.NewHexEncodedString(ed25519.Sign(s.privateKey, data))),
+	}, nil
+}
+
+func TestOffLedgerRequest(t *testing.T) {
+	chainID := isc.ChainID{3}
+	request := isc.NewOffLedgerRequest(chainID, isc.HnameAccounts, isc.FuncWithdraw, nil, 7, isc.DefaultGasBudget, isc.NewAssetsBaseTokens(1_000_000))
+
+	// kind, chain ID, contract, entry point, empty params, nonce, gas budget + 1, allowance with base tokens
+	expectedEssence := "01" + hex.EncodeToString(chainID[:]) + "025e4b3c" + "410fcc9d" + "00" + "07" + "c1843d" + "80c0843d"
+	require.Equal(t, expectedEssence, hex.EncodeToString(request.EssenceBytes()))
+
+	signer := &ed25519TestSigner{privateKey: ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))}
+	signed, err := request.Sign(signer, types.NewBip44Chain(types.CoinTypeSMR, 0, 0, false))
+	require.NoError(t, err)
+
+	wire := signed.Bytes()
+	require.Len(t, wire, len(request.EssenceBytes())+ed25519.PublicKeySize+1+ed25519.SignatureSize)
+
+	decoded, err := isc.OffLedgerRequestFromBytes(wire)
+	require.NoError(t, err)
+	require.Equal(t, signed.ID(), decoded.ID())
+	require.Equal(t, request.Nonce, decoded.Nonce)
+	require.Equal(t, request.Allowance, decoded.Allowance)
+	require.Equal(t, signed.SenderAddress(), decoded.SenderAddress())
+	require.Len(t, signed.ID().String(), 2+2*isc.RequestIDLength)
+
+	// Tampering invalidates the signature
+	wire[len(request.EssenceBytes())-1] ^= 1
+	_, err = isc.OffLedgerRequestFromBytes(wire)
+	require.Error(t, err)
+
+	_, err = request.WithSignature(signed.PublicKey(), make([]byte, ed25519.SignatureSize))
+	require.ErrorIs(t, err, isc.ErrInvalidSignature)
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestHnames(t *testing.T) {
+	require.Equal(t, "3c4b5e02", isc.HnameAccounts.String())
+	require.Equal(t, "bdc9102d", isc.FuncDeposit.String())
+	require.Equal(t, "9dcc0f41", isc.FuncWithdraw.String())
+	require.Equal(t, "23f4e3a1", isc.FuncTransferAllowanceTo.String())
+	require.Equal(t, isc.Hname(0x07cb02c1), isc.HnameFromString("evm"))
+
+	hname, err := isc.ParseHname("3c4b5e02")
+	require.NoError(t, err)
+	require.Equal(t, isc.HnameAccounts, hname)
+	require.Equal(t, []byte{0x02, 0x5e, 0x4b, 0x3c}, hname.Bytes())
+}
+
+func TestBech32Address(t *testing.T) {
+	// Vectors from TIP-31
+	pubKeyHash := types.HexEncodedString("0xefdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3")
+	address := types.Address{Type: types.AddressTypeEd25519, PubKeyHash: pubKeyHash}
+
+	encoded, err := isc.AddressToBech32("iota", address)
+	require.NoError(t, err)
+	require.Equal(t, "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx", encoded)
+
+	hrp, decoded, err := isc.AddressFromBech32("smr1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xhcazjh")
+	require.NoError(t, err)
+	require.Equal(t, "smr", hrp)
+	require.Equal(t, address, decoded)
+
+	_, _, err = isc.AddressFromBech32("iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyy")
+	require.ErrorIs(t, err, isc.ErrInvalidBech32)
+}
+
+func TestChainID(t *testing.T) {
+	aliasId := types.HexEncodedString("0x" + strings.Repeat("ab", 32))
+
+	chainID, err := isc.ChainIDFromAliasId(aliasId)
+	require.NoError(t, err)
+	require.Equal(t, aliasId, chainID.AliasId())
+
+	hrp, decoded, err := isc.ChainIDFromBech32(chainID.Bech32("rms"))
+	require.NoError(t, err)
+	require.Equal(t, "rms", hrp)
+	require.Equal(t, chainID, decoded)
+
+	_, err = isc.ChainIDFromAliasId("0x1234")
+	require.Error(t, err)
+}
+
+func TestDepositRequestMetadata(t *testing.T) {
+	request := isc.NewDepositRequest(isc.ChainID{1}, 1_000_000)
+
+	metadata := request.Metadata().Bytes()
+	require.Equal(t, "00025e4b3c2d10c9bdc1843d0000", hex.EncodeToString(metadata))
+
+	decoded, err := isc.RequestMetadataFromBytes(metadata)
+	require.NoError(t, err)
+	require.Equal(t, isc.HnameAccounts, decoded.TargetContract)
+	require.Equal(t, isc.FuncDeposit, decoded.EntryPoint)
+	require.Equal(t, isc.DefaultGasBudget, decoded.GasBudget)
+	require.Nil(t, decoded.Allowance)
+
+	_, err = isc.RequestMetadataFromBytes(append(metadata, 0))
+	require.ErrorIs(t, err, isc.ErrInvalidRequest)
+}
+
+func TestTransferAllowanceToRequest(t *testing.T) {
+	chainID := isc.ChainID{2}
+	evmAddress, err := isc.EthereumAddressFromHex("0x" + strings.Repeat("11", 20))
+	require.NoError(t, err)
+
+	target := isc.NewEthereumAddressAgentID(chainID, evmAddress)
+	require.Equal(t, append(append([]byte{byte(isc.AgentIDKindEthereumAddress)}, chainID[:]...), evmAddress[:]...), target.Bytes())
+
+	tokenId, err := isc.NativeTokenIDFromHex(types.HexEncodedString("0x08" + strings.Repeat("cd", 37)))
+	require.NoError(t, err)
+
+	allowance := &isc.Assets{
+		BaseTokens:   900_000,
+		NativeTokens: []isc.NativeToken{{ID: tokenId, Amount: big.NewInt(500)}},
+	}
+	request := isc.NewTransferAllowanceToRequest(chainID, target, allowance, 1_000_000)
+	request.GasBudget = isc.GasBudgetUnlimited
+
+	decoded, err := isc.RequestMetadataFromBytes(request.Metadata().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, isc.FuncTransferAllowanceTo, decoded.EntryPoint)
+	require.Equal(t, isc.GasBudgetUnlimited, decoded.GasBudget)
+	require.Equal(t, target.Bytes(), decoded.Params[isc.ParamAgentID])
+	require.Equal(t, allowance, decoded.Allowance)
+
+	sender := types.Address{Type: types.AddressTypeEd25519, PubKeyHash: types.HexEncodedString("0x" + strings.Repeat("01", 32))}
+	output, err := request.Output(sender)
+	require.NoError(t, err)
+
+	parsed, err := types.ParseOutput(output)
+	require.NoError(t, err)
+	require.Equal(t, types.OutputTypeBasic, parsed.Type)
+	require.Equal(t, "1000000", parsed.Amount)
+	require.Equal(t, chainID.Address(), *parsed.UnlockCondition(types.UnlockConditionTypeAddress).Address)
+	require.Equal(t, sender, *parsed.Feature(types.FeatureTypeSender).Address)
+	require.Equal(t, types.NewHexEncodedString(request.Metadata().Bytes()), parsed.Feature(types.FeatureTypeMetadata).Data)
+
+	request.BaseTokens = 0
+	_, err = request.Output(sender)
+	require.ErrorIs(t, err, isc.ErrInvalidRequest)
+}
@@ -0,0 +1,426 @@
+package test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/iotaledger/wasp-wallet-sdk/hdwallet"
+	"github.com/iotaledger/wasp-wallet-sdk/isc"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// waspStandIn mimics the Wasp API endpoints used for withdrawals
+type waspStandIn struct {
+	t        *testing.T
+	hrp      string
+	nonce    uint64
+	errorMsg string
+
+	mutex          sync.Mutex
+	posted         []*isc.SignedOffLedgerRequest
+	evmTransaction []byte
+	evmMethods     []string
+	waited         []string
+}
+
+func (w *waspStandIn) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/nonce"):
+		assert.True(w.t, strings.HasPrefix(r.URL.Path, "/v1/chains/"+w.hrp+"1"))
+		assert.NoError(w.t, json.NewEncoder(rw).Encode(map[string]string{"nonce": fmt.Sprint(w.nonce)}))
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/requests/offledger":
+		var body struct {
+			ChainID string `json:"chainId"`
+			Request string `json:"request"`
+		}
+		if !assert.NoError(w.t, json.NewDecoder(r.Body).Decode(&body)) {
+			http.Error(rw, "invalid body", http.StatusBadRequest)
+			return
+		}
+
+		data, err := types.HexEncodedString(body.Request).Bytes()
+		assert.NoError(w.t, err)
+
+		request, err := isc.OffLedgerRequestFromBytes(data)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		assert.Equal(w.t, request.ChainID.Bech32(w.hrp), body.ChainID)
+
+		w.posted = append(w.posted, request)
+		rw.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/evm"):
+		w.serveEVM(rw, r)
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/wait"):
+		assert.Equal(w.t, "5", r.URL.Query().Get("timeoutSeconds"))
+		w.waited = append(w.waited, strings.Split(r.URL.Path, "/")[5])
+		assert.NoError(w.t, json.NewEncoder(rw).Encode(isc.Receipt{
+			BlockIndex:   3,
+			GasBurned:    "100",
+			ErrorMessage: w.errorMsg,
+		}))
+	default:
+		http.NotFound(rw, r)
+	}
+}
+
+// serveEVM answers the EVM JSON-RPC calls of an EVM withdrawal
+func (w *waspStandIn) serveEVM(rw http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if !assert.NoError(w.t, json.NewDecoder(r.Body).Decode(&request)) {
+		http.Error(rw, "invalid body", http.StatusBadRequest)
+		return
+	}
+	w.evmMethods = append(w.evmMethods, request.Method)
+
+	var result string
+	switch request.Method {
+	case "eth_chainId":
+		result = "0x432"
+	case "eth_getTransactionCount":
+		result = fmt.Sprintf("0x%x", w.nonce)
+	case "eth_gasPrice":
+		result = "0x2540be400"
+	case "eth_estimateGas":
+		result = "0x1d4c0"
+	case "eth_sendRawTransaction":
+		var raw string
+		assert.NoError(w.t, json.Unmarshal(request.Params[0], &raw))
+
+		data, err := types.HexEncodedString(raw).Bytes()
+		assert.NoError(w.t, err)
+		w.evmTransaction = data
+
+		hash := sha3.NewLegacyKeccak256()
+		hash.Write(data)
+		result = string(types.NewHexEncodedString(hash.Sum(nil)))
+	default:
+		assert.NoError(w.t, json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "error": map[string]any{"code": -32601, "message": "method not found"}}))
+		return
+	}
+
+	assert.NoError(w.t, json.NewEncoder(rw).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result}))
+}
+
+func withdrawTestSetup(t *testing.T) (*waspStandIn, *isc.WaspClient, *hdwallet.SecretManager, *isc.AddressAgentID) {
+	standIn := &waspStandIn{t: t, hrp: "smr", nonce: 42}
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+
+	signer, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+	t.Cleanup(signer.Destroy)
+
+	address, err := signer.GenerateEd25519Address(0, 0, "", types.CoinTypeSMR, nil)
+	require.NoError(t, err)
+
+	from, err := isc.AgentIDFromBech32Address(address)
+	require.NoError(t, err)
+
+	return standIn, isc.NewWaspClient(server.URL+"/", "smr", server.Client()), signer, from
+}
+
+func TestSendWithdrawRequest(t *testing.T) {
+	standIn, client, signer, from := withdrawTestSetup(t)
+	chainID := isc.ChainID{7}
+
+	request, err := isc.SendWithdrawRequest(context.Background(), client, signer, types.NewBip44Chain(types.CoinTypeSMR, 0, 0, false), isc.WithdrawParams{
+		ChainID: chainID,
+		Assets:  isc.NewAssetsBaseTokens(1_000_000),
+		From:    from,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, standIn.posted, 1)
+	posted := standIn.posted[0]
+	require.Equal(t, request.ID(), posted.ID())
+	require.Equal(t, chainID, posted.ChainID)
+	require.Equal(t, isc.HnameAccounts, posted.Contract)
+	require.Equal(t, isc.FuncWithdraw, posted.EntryPoint)
+	require.Equal(t, uint64(42), posted.Nonce)
+	require.Equal(t, uint64(isc.DefaultGasBudget), posted.GasBudget)
+	require.Equal(t, isc.NewAssetsBaseTokens(1_000_000), posted.Allowance)
+
+	receipt, err := client.WaitForReceipt(context.Background(), chainID, request.ID(), 5*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), receipt.BlockIndex)
+
+	standIn.errorMsg = "not enough funds"
+	receipt, err = client.WaitForReceipt(context.Background(), chainID, request.ID(), 5*time.Second)
+	require.ErrorIs(t, err, isc.ErrRequestFailed)
+	require.Equal(t, "not enough funds", receipt.ErrorMessage)
+}
+
+func TestSendWithdrawRequestEVM(t *testing.T) {
+	standIn, client, signer, from := withdrawTestSetup(t)
+	chainID := isc.ChainID{7}
+
+	evmAddresses, err := signer.GenerateEvmAddresses(types.NewRange(0, 1), 0, "", nil)
+	require.NoError(t, err)
+
+	evmAgentID, err := isc.AgentIDFromEvmAddress(chainID, evmAddresses[0])
+	require.NoError(t, err)
+
+	target := from.Address()
+	assets := isc.NewAssetsBaseTokens(1_000_000)
+	request, err := isc.SendWithdrawRequest(context.Background(), client, signer, types.NewBip44Chain(types.CoinTypeEther, 0, 0, false), isc.WithdrawParams{
+		ChainID: chainID,
+		Assets:  assets,
+		From:    evmAgentID,
+		To:      &target,
+	})
+	require.NoError(t, err)
+	require.Empty(t, standIn.posted)
+	require.Equal(t, []string{"eth_chainId", "eth_getTransactionCount", "eth_gasPrice", "eth_estimateGas", "eth_sendRawTransaction"}, standIn.evmMethods)
+
+	transaction, ok := request.(*isc.SignedEVMTransaction)
+	require.True(t, ok)
+	require.Equal(t, standIn.evmTransaction, transaction.Bytes())
+	require.Equal(t, evmAgentID.EthereumAddress(), transaction.Sender())
+	require.Equal(t, uint64(0x432), transaction.EVMChainID)
+	require.Equal(t, uint64(42), transaction.Nonce)
+	require.Equal(t, uint64(0x1d4c0), transaction.Gas)
+	require.Equal(t, isc.MagicContractAddress, transaction.To)
+
+	expectedData, err := isc.NewMagicSendCallData(target, assets)
+	require.NoError(t, err)
+	require.Equal(t, expectedData, transaction.Data)
+
+	// The request ID of an EVM transaction is its hash
+	hash, id := transaction.Hash(), request.ID()
+	require.Equal(t, hash[:], id[:32])
+
+	// A key which doesn't belong to the EVM account is rejected before sending
+	standIn.evmTransaction = nil
+	_, err = isc.SendWithdrawRequest(context.Background(), client, signer, types.NewBip44Chain(types.CoinTypeEther, 0, 1, false), isc.WithdrawParams{
+		ChainID: chainID,
+		Assets:  assets,
+		From:    evmAgentID,
+		To:      &target,
+	})
+	require.Error(t, err)
+	require.Nil(t, standIn.evmTransaction)
+
+	// EVM accounts need an L1 address to withdraw to
+	_, err = isc.SendWithdrawRequest(context.Background(), client, signer, types.NewBip44Chain(types.CoinTypeEther, 0, 0, false), isc.WithdrawParams{
+		ChainID: chainID,
+		Assets:  assets,
+		From:    evmAgentID,
+	})
+	require.Error(t, err)
+}
+
+func TestMagicSendCallData(t *testing.T) {
+	target := types.Address{Type: types.AddressTypeEd25519, PubKeyHash: testChainAliasId}
+	data, err := isc.NewMagicSendCallData(target, isc.NewAssetsBaseTokens(1_000_000))
+	require.NoError(t, err)
+
+	word := func(i int) string {
+		return hex.EncodeToString(data[4+32*i : 4+32*(i+1)])
+	}
+
+	// The five arguments: offset of the L1 address, offset of the assets, bool, offsets of the metadata and of the options
+	require.Equal(t, fmt.Sprintf("%064x", 5*32), word(0))
+	require.Equal(t, fmt.Sprintf("%064x", 9*32), word(1))
+	require.Equal(t, fmt.Sprintf("%064x", 0), word(2))
+
+	// The L1 address is a tuple of the serialized address: offset, length and 33 bytes of data padded to 64
+	require.Equal(t, fmt.Sprintf("%064x", 32), word(5))
+	require.Equal(t, fmt.Sprintf("%064x", 33), word(6))
+	require.Equal(t, "00"+strings.TrimPrefix(testChainAliasId, "0x")[:62], word(7))
+
+	// The assets start with the base tokens
+	require.Equal(t, fmt.Sprintf("%064x", 1_000_000), word(9))
+	require.Zero(t, len(data[4:])%32)
+}
+
+func TestSendWithdrawRequestErrors(t *testing.T) {
+	standIn, client, signer, from := withdrawTestSetup(t)
+	params := isc.WithdrawParams{ChainID: isc.ChainID{7}, Assets: isc.NewAssetsBaseTokens(1_000_000)}
+	bip44Chain := types.NewBip44Chain(types.CoinTypeSMR, 0, 0, false)
+
+	_, err := isc.SendWithdrawRequest(context.Background(), client, signer, bip44Chain, params)
+	require.Error(t, err)
+
+	// EVM accounts of other chains can't be withdrawn from
+	evmParams := params
+	evmParams.From = isc.NewEthereumAddressAgentID(isc.ChainID{8}, isc.EthereumAddress{1})
+	_, err = isc.SendWithdrawRequest(context.Background(), client, signer, bip44Chain, evmParams)
+	require.Error(t, err)
+
+	emptyParams := params
+	emptyParams.From = from
+	emptyParams.Assets = nil
+	_, err = isc.SendWithdrawRequest(context.Background(), client, signer, bip44Chain, emptyParams)
+	require.Error(t, err)
+
+	// A key which doesn't belong to the account is rejected before posting
+	otherParams := params
+	otherParams.From = isc.NewAddressAgentID(types.Address{Type: types.AddressTypeEd25519, PubKeyHash: testChainAliasId})
+	_, err = isc.SendWithdrawRequest(context.Background(), client, signer, bip44Chain, otherParams)
+	require.Error(t, err)
+	require.Empty(t, standIn.posted)
+	require.Empty(t, standIn.evmMethods)
+}
+
+// withdrawTestAccount is an L1 account which receives the output of the chain after the withdrawal was posted
+type withdrawTestAccount struct {
+	address  string
+	standIn  *waspStandIn
+	chainOut types.OutputData
+	syncs    int
+}
+
+func (a *withdrawTestAccount) Details() (*types.AccountDetails, error) {
+	return &types.AccountDetails{
+		CoinType:        types.CoinTypeSMR,
+		PublicAddresses: []types.AccountAddress{{Address: a.address}},
+	}, nil
+}
+
+func (a *withdrawTestAccount) Sync(context.Context, *types.SyncOptions) (*types.Balance, error) {
+	a.syncs++
+	return &types.Balance{}, nil
+}
+
+func (a *withdrawTestAccount) UnspentOutputs(*types.FilterOptions) ([]types.OutputData, error) {
+	a.standIn.mutex.Lock()
+	defer a.standIn.mutex.Unlock()
+
+	// The chain only sends the output once it processed the request
+	if len(a.standIn.waited) == 0 {
+		return nil, nil
+	}
+
+	return []types.OutputData{a.chainOut}, nil
+}
+
+func TestWithdraw(t *testing.T) {
+	standIn, client, signer, from := withdrawTestSetup(t)
+	chainID := isc.ChainID{7}
+
+	// An output the chain sent to the L1 address
+	output, err := isc.NewTransferAllowanceToRequest(chainID, from, nil, 1_000_000).Output(chainID.Address())
+	require.NoError(t, err)
+
+	address, err := isc.AddressToBech32("smr", from.Address())
+	require.NoError(t, err)
+
+	account := &withdrawTestAccount{
+		address:  address,
+		standIn:  standIn,
+		chainOut: types.OutputData{OutputId: "0x01", Output: output},
+	}
+
+	withdrawal, err := isc.Withdraw(context.Background(), account, signer, client, isc.WithdrawParams{
+		ChainID:        chainID,
+		Assets:         isc.NewAssetsBaseTokens(1_000_000),
+		ReceiptTimeout: 5 * time.Second,
+		PollInterval:   time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, standIn.posted, 1)
+	require.Equal(t, standIn.posted[0].ID(), withdrawal.Request.ID())
+	require.Equal(t, []string{withdrawal.Request.ID().String()}, standIn.waited)
+	require.Equal(t, uint32(3), withdrawal.Receipt.BlockIndex)
+	require.Equal(t, []types.OutputData{account.chainOut}, withdrawal.Outputs)
+	require.GreaterOrEqual(t, account.syncs, 2)
+
+	// A failed request doesn't wait for outputs
+	standIn.errorMsg = "not enough funds"
+	_, err = isc.Withdraw(context.Background(), account, signer, client, isc.WithdrawParams{
+		ChainID:        chainID,
+		Assets:         isc.NewAssetsBaseTokens(1_000_000),
+		ReceiptTimeout: 5 * time.Second,
+	})
+	require.ErrorIs(t, err, isc.ErrRequestFailed)
+}
+
+func TestWithdrawForeignAddress(t *testing.T) {
+	standIn, client, signer, from := withdrawTestSetup(t)
+
+	address, err := isc.AddressToBech32("smr", from.Address())
+	require.NoError(t, err)
+
+	account := &withdrawTestAccount{address: address, standIn: standIn}
+
+	foreign, err := isc.AgentIDFromBech32Address("smr1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xhcazjh")
+	require.NoError(t, err)
+
+	// Withdrawing from an address of another account would wait for its outputs forever
+	_, err = isc.Withdraw(context.Background(), account, signer, client, isc.WithdrawParams{
+		ChainID: isc.ChainID{7},
+		From:    foreign,
+		Assets:  isc.NewAssetsBaseTokens(1_000_000),
+	})
+	require.ErrorIs(t, err, isc.ErrForeignAddress)
+
+	// As would withdrawing from an EVM account to it
+	foreignAddress := foreign.Address()
+	_, err = isc.Withdraw(context.Background(), account, signer, client, isc.WithdrawParams{
+		ChainID: isc.ChainID{7},
+		From:    isc.NewEthereumAddressAgentID(isc.ChainID{7}, [20]byte{1}),
+		To:      &foreignAddress,
+		Assets:  isc.NewAssetsBaseTokens(1_000_000),
+	})
+	require.ErrorIs(t, err, isc.ErrForeignAddress)
+
+	require.Empty(t, standIn.posted)
+	require.Empty(t, standIn.evmMethods)
+}
+
+func TestWaspClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Error(rw, `{"Message":"chain not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := isc.NewWaspClient(server.URL, "smr", nil)
+	agentID := isc.NewAddressAgentID(types.Address{Type: types.AddressTypeEd25519, PubKeyHash: testChainAliasId})
+
+	_, err := client.AccountNonce(context.Background(), isc.ChainID{7}, agentID)
+
+	var apiError *isc.WaspAPIError
+	require.ErrorAs(t, err, &apiError)
+	require.Equal(t, http.StatusNotFound, apiError.StatusCode)
+	require.Contains(t, apiError.Body, "chain not found")
+}
+
+func TestWaspClientEscapesAgentID(t *testing.T) {
+	chainID := isc.ChainID{7}
+	agentID := isc.NewEthereumAddressAgentID(chainID, isc.EthereumAddress{1})
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		_, _ = rw.Write([]byte(`{"nonce":"1"}`))
+	}))
+	defer server.Close()
+
+	nonce, err := isc.NewWaspClient(server.URL, "smr", nil).AccountNonce(context.Background(), chainID, agentID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), nonce)
+	require.Contains(t, path, url.PathEscape(agentID.Bech32("smr")))
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/hdwallet"
+	"github.com/iotaledger/wasp-wallet-sdk/keychain"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestParseKeychainSpec(t *testing.T) {
+	for input, expected := range map[string]keychain.Spec{
+		"mnemonic":                 {Kind: keychain.KindMnemonic},
+		"Ledger":                   {Kind: keychain.KindLedger},
+		"ledger:emulator":          {Kind: keychain.KindLedger, Arg: "emulator"},
+		"stronghold:./wallet.snap": {Kind: keychain.KindStronghold, Arg: "./wallet.snap"},
+		"stronghold:C:\\wallet":    {Kind: keychain.KindStronghold, Arg: "C:\\wallet"},
+		"keyring":                  {Kind: keychain.KindKeyring, Arg: keychain.DefaultKeyringService},
+		"keyring:wasp-cli":         {Kind: keychain.KindKeyring, Arg: "wasp-cli"},
+	} {
+		spec, err := keychain.ParseSpec(input)
+		require.NoError(t, err, input)
+		require.Equal(t, expected, spec, input)
+
+		reparsed, err := keychain.ParseSpec(spec.String())
+		require.NoError(t, err)
+		require.Equal(t, spec, reparsed)
+	}
+
+	for _, input := range []string{"", "stronghold", "ledger:usb", "mnemonic:words", "file:seed"} {
+		_, err := keychain.ParseSpec(input)
+		require.ErrorIs(t, err, keychain.ErrInvalidSpec, input)
+	}
+}
+
+func TestKeychainMissingSecrets(t *testing.T) {
+	// Secrets are checked before the native library is used
+	_, err := keychain.Open(nil, "mnemonic", keychain.Secrets{}, keychain.Options{})
+	require.ErrorIs(t, err, keychain.ErrSecretRequired)
+
+	provider, err := keychain.Open(nil, "stronghold:wallet.snap", keychain.Secrets{}, keychain.Options{})
+	require.ErrorIs(t, err, keychain.ErrSecretRequired)
+	require.Nil(t, provider)
+}
+
+func TestKeyringMnemonic(t *testing.T) {
+	keyring.MockInit()
+
+	_, err := keychain.LoadMnemonicFromKeyring("wasp-cli", keychain.DefaultKeyringUser)
+	require.ErrorIs(t, err, keychain.ErrMnemonicNotInKeyring)
+
+	_, err = keychain.Open(nil, "keyring:wasp-cli", keychain.Secrets{}, keychain.Options{})
+	require.ErrorIs(t, err, keychain.ErrMnemonicNotInKeyring)
+
+	mnemonic := "giant dynamic museum toddler six deny defense ostrich bomb access mercy blood explain muscle shoot shallow glad autumn author calm heavy hawk abuse rally"
+	require.NoError(t, keychain.StoreMnemonicInKeyring("wasp-cli", keychain.DefaultKeyringUser, memguard.NewEnclave([]byte(mnemonic))))
+
+	loaded, err := keychain.LoadMnemonicFromKeyring("wasp-cli", keychain.DefaultKeyringUser)
+	require.NoError(t, err)
+
+	buffer, err := loaded.Open()
+	require.NoError(t, err)
+	require.Equal(t, mnemonic, buffer.String())
+	buffer.Destroy()
+
+	require.NoError(t, keychain.DeleteMnemonicFromKeyring("wasp-cli", keychain.DefaultKeyringUser))
+	require.ErrorIs(t, keychain.DeleteMnemonicFromKeyring("wasp-cli", keychain.DefaultKeyringUser), keychain.ErrMnemonicNotInKeyring)
+}
+
+func TestKeychainPublicKey(t *testing.T) {
+	// The public key is taken from the signature of a fixed message, only once per address
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_secret_manager", "request": {"mnemonic": "[REDACTED]"}, "result": 1},
+    {"call": "call_secret_manager_method", "handle": 1,
+      "request": {"name": "signEd25519", "data": {"chain": {"account": 0, "addressIndex": 3, "change": 0, "coinType": 4219}, "message": "0x54cac3340333079c013ff92e75fc4c24da46f2a346ace79a986a096b6a50ede8"}},
+      "response": {"type": "ed25519Signature", "payload": {"type": 0, "publicKey": "0xe3abf68eedb04a8c2c52eb0a75d324eb3f9dd8cab2b0ee6fb6b960c249314f7a", "signature": "0x279a229f8808baea9dfc6ce9aeddd14bb9404b06a1dd3cde1b3f083b15d86d283ada2db8b3501750ed9f3083a76710b300a04ba50a657ebf8f0c99d766780006"}}}
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	options := keychain.Options{CoinType: types.CoinTypeSMR}
+	provider, err := keychain.Open(sdk, "mnemonic", keychain.Secrets{Mnemonic: memguard.NewEnclave([]byte(Mnemonic))}, options)
+	require.NoError(t, err)
+	defer provider.Close()
+
+	signer, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+	defer signer.Destroy()
+
+	expected, err := signer.Ed25519PublicKey(types.NewBip44Chain(types.CoinTypeSMR, 0, 3, false))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		publicKey, err := provider.PublicKey(3)
+		require.NoError(t, err)
+		require.Equal(t, []byte(expected), publicKey)
+	}
+}
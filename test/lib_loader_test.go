@@ -0,0 +1,101 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/lib_loader"
+)
+
+func TestFindLibraryFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	libPath := filepath.Join(dir, lib_loader.LibraryFileName)
+	require.NoError(t, os.WriteFile(libPath, []byte{}, 0o600))
+
+	t.Setenv(lib_loader.LibraryPathEnvVar, libPath)
+	found, err := lib_loader.FindLibrary()
+	require.NoError(t, err)
+	require.Equal(t, libPath, found)
+
+	t.Setenv(lib_loader.LibraryPathEnvVar, dir)
+	found, err = lib_loader.FindLibrary()
+	require.NoError(t, err)
+	require.Equal(t, libPath, found)
+	require.Equal(t, libPath, lib_loader.SearchPaths()[0])
+}
+
+func TestFindLibraryNotFound(t *testing.T) {
+	t.Setenv(lib_loader.LibraryPathEnvVar, t.TempDir())
+
+	_, err := lib_loader.FindLibrary()
+	require.ErrorIs(t, err, lib_loader.ErrLibraryNotFound)
+}
+
+func TestParseVersion(t *testing.T) {
+	version, err := lib_loader.ParseVersion("v1.1.4-rc.2")
+	require.NoError(t, err)
+	require.Equal(t, lib_loader.Version{Major: 1, Minor: 1, Patch: 4}, version)
+	require.Equal(t, "1.1.4", version.String())
+
+	minimum, err := lib_loader.ParseVersion(wasp_wallet_sdk.MinimumBindingVersion)
+	require.NoError(t, err)
+	require.Equal(t, 1, version.Compare(minimum))
+	require.Equal(t, 0, minimum.Compare(minimum))
+	require.Equal(t, -1, lib_loader.Version{Major: 1}.Compare(minimum))
+	require.Equal(t, 1, lib_loader.Version{Major: 2}.Compare(minimum))
+
+	for _, invalid := range []string{"", "1", "1.2", "1.2.x", "1.2.3.4"} {
+		_, err = lib_loader.ParseVersion(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestNewIotaSDKIncompatibleLibrary(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses the system libc as a library without SDK symbols")
+	}
+
+	sdk, err := wasp_wallet_sdk.NewIotaSDK("libc.so.6")
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrIncompatibleLibrary)
+	require.ErrorContains(t, err, "create_secret_manager")
+	require.Nil(t, sdk)
+}
+
+// versionProbeRecording replays the version probe of a library without a version symbol
+func versionProbeRecording(t *testing.T, lastError string) string {
+	return writeRecording(t, `{
+  "calls": [
+    {
+      "call": "call_utils_method",
+      "request": {"data": {}, "name": "verifySecp256k1EcdsaSignature"},
+      "error": "`+lastError+`"
+    }
+  ]
+}`)
+}
+
+func TestCheckVersionWithoutVersionSymbol(t *testing.T) {
+	for lastError, expected := range map[string]string{
+		"missing field `publicKey` at line 1 column 53":                        "",
+		"unknown variant `verifySecp256k1EcdsaSignature`, expected one of ...": "older than the minimum supported version",
+		// The version is unknown, but the method is known
+		"method call failed": "",
+	} {
+		sdk, err := wasp_wallet_sdk.NewReplaySDK(versionProbeRecording(t, lastError))
+		require.NoError(t, err)
+
+		err = sdk.CheckVersion()
+		if expected == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, expected)
+		}
+
+		sdk.Destroy()
+	}
+}
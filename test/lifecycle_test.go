@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestSecretManagerClose(t *testing.T) {
+	sdk := GetOrInitTest(t)
+	liveHandles := len(sdk.LiveHandles())
+
+	secretManager, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+	require.Len(t, sdk.LiveHandles(), liveHandles+1)
+
+	require.NoError(t, secretManager.Close())
+	require.Len(t, sdk.LiveHandles(), liveHandles)
+
+	// Closing twice must not destroy the native handle again
+	require.NoError(t, secretManager.Close())
+}
+
+func TestSecretManagerCallsAfterClose(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_secret_manager", "request": {"mnemonic": "[REDACTED]"}, "result": 1},
+    {"call": "call_secret_manager_method", "handle": 1, "request": {"name": "getLedgerNanoStatus", "data": null}, "response": {"type": "ledgerNanoStatus", "payload": {"connected": false, "locked": false, "blindSigningEnabled": false, "bufferSize": 0}}}
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	secretManager, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+
+	// The secret manager is closed during the call, it's destroyed once the call returned
+	sdk.Use(func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+		require.NoError(t, secretManager.Close())
+		require.Len(t, sdk.LiveHandles(), 1)
+
+		return next(ctx)
+	})
+
+	_, err = secretManager.GetLedgerStatus()
+	require.NoError(t, err)
+	require.Empty(t, sdk.LiveHandles())
+
+	// Later calls don't reach the library
+	_, err = secretManager.GetLedgerStatus()
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+	_, err = secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+}
+
+func TestWalletCallsAfterClose(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_wallet", "request": {"coinType": 4219}, "result": 1},
+    {"call": "get_client_from_wallet", "handle": 1, "result": 2},
+    {"call": "get_secret_manager_from_wallet", "handle": 1, "result": 3},
+    {"call": "call_wallet_method", "handle": 1, "request": {"name": "getAccountIndexes", "data": null}, "response": {"type": "accountIndexes", "payload": [0]}}
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	wallet, err := sdk.CreateWallet(types.WalletOptions{CoinType: types.CoinTypeSMR})
+	require.NoError(t, err)
+
+	secretManager, err := wallet.SecretManager()
+	require.NoError(t, err)
+	defer secretManager.Destroy()
+
+	// The wallet is closed during the call, it's destroyed once the call returned
+	sdk.Use(func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+		require.NoError(t, wallet.Close())
+		require.Len(t, sdk.LiveHandles(), 3)
+
+		return next(ctx)
+	})
+
+	indexes, err := wallet.GetAccountIndexes()
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, indexes)
+
+	// Only the secret manager, which is still owned, is left
+	require.Len(t, sdk.LiveHandles(), 1)
+
+	// Later calls don't reach the library, also not the ones of the secret manager of the wallet
+	_, err = wallet.GetAccounts()
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+	_, err = wallet.GenerateEd25519Address(0, 0, "", types.CoinTypeSMR, nil)
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+	_, err = wallet.SecretManager()
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+	require.ErrorIs(t, wallet.Listen(nil, func(types.WalletEvent) {}), wasp_wallet_sdk.ErrHandleClosed)
+	require.NoError(t, wallet.Close())
+}
+
+func TestLeakDetection(t *testing.T) {
+	sdk := GetOrInitTest(t)
+
+	leaks := make(chan wasp_wallet_sdk.LeakReport, 1)
+	sdk.SetLeakDetection(true, func(report wasp_wallet_sdk.LeakReport) {
+		leaks <- report
+	})
+	defer sdk.SetLeakDetection(false, nil)
+
+	func() {
+		_, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(Mnemonic)))
+		require.NoError(t, err)
+	}()
+
+	runtime.GC()
+
+	select {
+	case report := <-leaks:
+		require.Equal(t, wasp_wallet_sdk.HandleKindSecretManager, report.Kind)
+		require.Contains(t, report.CreationStack, "TestLeakDetection")
+	case <-time.After(5 * time.Second):
+		t.Fatal("leaked secret manager was not reported")
+	}
+}
+
+func TestSDKCloseTwice(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_secret_manager", "request": {"mnemonic": "[REDACTED]"}, "result": 1}
+  ]
+}`))
+	require.NoError(t, err)
+
+	_, err = wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+	require.Len(t, sdk.LiveHandles(), 1)
+
+	require.NoError(t, sdk.Close())
+	require.Empty(t, sdk.LiveHandles())
+
+	// Closing again must not unload the library again
+	require.NoError(t, sdk.Close())
+	sdk.Destroy()
+}
+
+func TestCreateAccountCancelledDuringCall(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_wallet", "request": {"coinType": 4219}, "result": 1},
+    {"call": "get_client_from_wallet", "handle": 1, "result": 2},
+    {"call": "get_secret_manager_from_wallet", "handle": 1, "result": 3},
+    {"call": "call_wallet_method", "handle": 1, "request": {"name": "createAccount", "data": {"bech32Hrp": "smr"}}, "response": {"type": "account", "payload": {"index": 0, "coinType": 4219, "alias": "0"}}}
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	wallet, err := sdk.CreateWallet(types.WalletOptions{CoinType: types.CoinTypeSMR})
+	require.NoError(t, err)
+
+	// Neither cancelling nor closing the wallet during the call loses the created account
+	ctx, cancel := context.WithCancel(context.Background())
+	sdk.Use(func(ctx context.Context, _ wasp_wallet_sdk.Domain, _ string, next wasp_wallet_sdk.Next) error {
+		cancel()
+		require.NoError(t, wallet.Close())
+		require.NotEmpty(t, sdk.LiveHandles())
+
+		return next(ctx)
+	})
+
+	account, err := wallet.CreateAccount(ctx, types.CreateAccountOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "0", account.Alias)
+	require.Empty(t, sdk.LiveHandles())
+
+	// A context that is done before the call fails it
+	_, err = wallet.CreateAccount(ctx, types.CreateAccountOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,194 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// recordingHandler keeps all records it handles
+type recordingHandler struct {
+	mutex   sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.records = append(h.records, record)
+
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordingHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func recordAttr(record slog.Record, key string) string {
+	var value string
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == key {
+			value = attr.Value.String()
+			return false
+		}
+		return true
+	})
+
+	return value
+}
+
+func TestRedactSecrets(t *testing.T) {
+	mnemonic := "giant dynamic museum toddler six deny defense ostrich bomb access mercy blood explain muscle shoot shallow glad autumn author calm heart december mercy neck"
+	privateKey := "0x" + strings.Repeat("ab", 32)
+	expandedKey := strings.Repeat("cd", 64)
+	transactionID := "0x" + strings.Repeat("01", 32)
+	outputID := "0x" + strings.Repeat("ef", 32) + "0000"
+
+	require.Equal(t, "importing [REDACTED]", wasp_wallet_sdk.RedactSecrets("importing "+mnemonic))
+	require.Equal(t, "private key [REDACTED], secret_key=[REDACTED]", wasp_wallet_sdk.RedactSecrets("private key "+privateKey+", secret_key="+expandedKey))
+	require.Equal(t, `{\"privateKey\":\"[REDACTED]\",\"hexSeed\": \"[REDACTED]\"}`, wasp_wallet_sdk.RedactSecrets(`{\"privateKey\":\"`+privateKey+`\",\"hexSeed\": \"`+expandedKey+`\"}`))
+
+	// IDs and regular messages are kept
+	require.Equal(t, "transaction "+transactionID+" included", wasp_wallet_sdk.RedactSecrets("transaction "+transactionID+" included"))
+	require.Equal(t, "output "+outputID, wasp_wallet_sdk.RedactSecrets("output "+outputID))
+	require.Equal(t, "syncing account 0 with 3 addresses took 120ms", wasp_wallet_sdk.RedactSecrets("syncing account 0 with 3 addresses took 120ms"))
+}
+
+func TestTailNativeLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "native.log")
+	require.NoError(t, os.WriteFile(path, []byte("[2024-03-01][11:59:59][iota_sdk::client][INFO] before the bridge\n"), 0o600))
+
+	handler := &recordingHandler{}
+	bridge, err := wasp_wallet_sdk.TailNativeLog(slog.New(handler), path)
+	require.NoError(t, err)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString("[2024-03-01][12:00:00][iota_sdk::wallet::core][DEBUG] syncing\n" +
+		"[2024-03-01][12:00:01][iota_sdk::client::node_manager][WARN] \x1b[33mnode unhealthy\x1b[0m\n" +
+		"  caused by: timeout\n" +
+		"[2024-03-01][12:00:02][iota_sdk::client::secret][TRACE] signing with private key 0x" + strings.Repeat("01", 32) + "\n")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		handler.mutex.Lock()
+		defer handler.mutex.Unlock()
+
+		return len(handler.records) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A partial line is forwarded on close
+	_, err = file.WriteString("[2024-03-01][12:00:03][iota_sdk::wallet][ERROR] shutting down")
+	require.NoError(t, err)
+	require.NoError(t, bridge.Close())
+
+	records := handler.records
+	require.Len(t, records, 4)
+
+	require.Equal(t, slog.LevelDebug, records[0].Level)
+	require.Equal(t, "syncing", records[0].Message)
+	require.Equal(t, "iota_sdk::wallet::core", recordAttr(records[0], "target"))
+	require.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local), records[0].Time)
+
+	require.Equal(t, slog.LevelWarn, records[1].Level)
+	require.Equal(t, "node unhealthy\n  caused by: timeout", records[1].Message)
+
+	require.Equal(t, wasp_wallet_sdk.LevelTrace, records[2].Level)
+	require.Equal(t, "signing with private key [REDACTED]", records[2].Message)
+
+	require.Equal(t, slog.LevelError, records[3].Level)
+	require.Equal(t, "shutting down", records[3].Message)
+}
+
+func TestInitSlogLoggerTruncates(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{"calls": []}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	handler := &recordingHandler{}
+	bridge, err := sdk.InitSlogLogger(slog.New(handler), types.ILoggerConfig{})
+	require.NoError(t, err)
+
+	// The native logger appends to the file
+	file, err := os.OpenFile(bridge.Path(), os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	defer file.Close()
+
+	for index, line := range []string{
+		"[2024-03-01][12:00:00][iota_sdk::wallet][INFO] first\n",
+		"[2024-03-01][12:00:01][iota_sdk::wallet][INFO] second\n",
+	} {
+		_, err = file.WriteString(line)
+		require.NoError(t, err)
+
+		// Forwarded records are removed from the file
+		require.Eventually(t, func() bool {
+			handler.mutex.Lock()
+			defer handler.mutex.Unlock()
+
+			info, err := os.Stat(bridge.Path())
+			return len(handler.records) == index+1 && err == nil && info.Size() == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	require.Equal(t, "second", handler.records[1].Message)
+
+	require.NoError(t, bridge.Close())
+	_, err = os.Stat(bridge.Path())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMethodName(t *testing.T) {
+	require.Equal(t, "getAccountIndexes", methods.GetAccountIndexesMethod().MethodName())
+
+	call := types.BaseCallAccountMethod[types.BaseCallAccountMethodWrap[any]]{
+		AccountId: 0,
+		Method:    methods.SyncMethod(methods.SyncMethodData{}),
+	}
+	require.Equal(t, "callAccountMethod.sync", methods.CallAccountMethod(call).MethodName())
+}
+
+func TestMethodCallLogRedactsErrors(t *testing.T) {
+	// The native error echoes the mnemonic, recordings are redacted so the error is written here
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, fmt.Sprintf(`{
+  "calls": [
+    {"call": "call_utils_method", "request": {"name": "verifyMnemonic", "data": {"mnemonic": "[REDACTED]"}}, "error": "invalid mnemonic: %s"}
+  ]
+}`, Mnemonic)))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	handler := &recordingHandler{}
+	sdk.SetLogger(slog.New(handler))
+
+	require.Error(t, sdk.Utils().VerifyMnemonic(memguard.NewEnclave([]byte(Mnemonic))))
+
+	records := handler.records
+	require.Len(t, records, 1)
+	require.Equal(t, "verifyMnemonic", recordAttr(records[0], "method"))
+	require.Equal(t, "invalid mnemonic: [REDACTED]", recordAttr(records[0], "error"))
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestHexEncodedAmount(t *testing.T) {
+	amount, err := types.NewHexEncodedAmount(big.NewInt(1_000_000))
+	require.NoError(t, err)
+	require.Equal(t, types.HexEncodedAmount("0xf4240"), amount)
+
+	decoded, err := amount.BigInt()
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000_000), decoded.Int64())
+
+	_, err = types.NewHexEncodedAmount(big.NewInt(-1))
+	require.Error(t, err)
+
+	_, err = types.NewHexEncodedAmount(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+
+	_, err = types.HexEncodedAmount("0xzz").BigInt()
+	require.Error(t, err)
+}
+
+func TestCreateNativeTokenParams(t *testing.T) {
+	metadata := types.NewIrc30Metadata("Wasp Token", "WSP", 6)
+	params := types.CreateNativeTokenParams{
+		CirculatingSupply: big.NewInt(100),
+		MaximumSupply:     big.NewInt(1000),
+		Metadata:          &metadata,
+	}
+
+	mintParams, err := params.MintNativeTokenParams()
+	require.NoError(t, err)
+	require.Equal(t, types.HexEncodedAmount("0x64"), mintParams.CirculatingSupply)
+	require.Equal(t, types.HexEncodedAmount("0x3e8"), mintParams.MaximumSupply)
+
+	balance := types.NativeTokenBalance{Metadata: mintParams.FoundryMetadata}
+	require.Equal(t, &metadata, balance.Irc30())
+	require.Nil(t, types.NativeTokenBalance{}.Irc30())
+
+	params.CirculatingSupply = big.NewInt(1001)
+	_, err = params.MintNativeTokenParams()
+	require.Error(t, err)
+
+	params.CirculatingSupply = big.NewInt(1)
+	params.Metadata = &types.Irc30Metadata{Standard: types.Irc30Standard, Name: "No symbol"}
+	_, err = params.MintNativeTokenParams()
+	require.ErrorIs(t, err, types.ErrInvalidIrc30Metadata)
+}
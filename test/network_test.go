@@ -0,0 +1,108 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestNetworkLookup(t *testing.T) {
+	network, err := types.NetworkByCoinType(types.CoinTypeSMR)
+	require.NoError(t, err)
+	require.Equal(t, types.NetworkShimmer, network)
+	require.Equal(t, "smr", types.CoinTypeSMR.DefaultBech32Hrp())
+	require.Equal(t, "iota", types.CoinTypeIOTA.DefaultBech32Hrp())
+	require.Empty(t, types.CoinTypeEther.DefaultBech32Hrp())
+
+	network, err = types.NetworkByBech32Hrp("rms")
+	require.NoError(t, err)
+	require.Equal(t, types.NetworkShimmer, network)
+	require.True(t, network.IsTestnetBech32Hrp("rms"))
+
+	_, err = types.NetworkByBech32Hrp("nope")
+	require.ErrorIs(t, err, types.ErrUnknownNetwork)
+
+	_, err = types.NetworkByCoinType(1)
+	require.ErrorIs(t, err, types.ErrUnknownNetwork)
+
+	require.Equal(t, "m/44'/4218'/0'/0'/0'", types.NetworkIOTA.DefaultBip44Chain().String())
+}
+
+func TestResolveBech32Hrp(t *testing.T) {
+	hrp, err := types.ResolveBech32Hrp(types.CoinTypeSMR, "")
+	require.NoError(t, err)
+	require.Equal(t, "smr", hrp)
+
+	hrp, err = types.ResolveBech32Hrp(types.CoinTypeSMR, "rms")
+	require.NoError(t, err)
+	require.Equal(t, "rms", hrp)
+
+	_, err = types.ResolveBech32Hrp(types.CoinTypeEther, "")
+	require.ErrorIs(t, err, types.ErrUnknownNetwork)
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	devnet := types.Network{
+		Name:        "wasp-devnet",
+		CoinType:    123456,
+		Bech32Hrp:   "tst",
+		Decimals:    6,
+		TokenSymbol: "TST",
+	}
+
+	require.NoError(t, types.RegisterNetwork(devnet))
+	defer func() {
+		require.NoError(t, types.UnregisterNetwork(devnet.Name))
+	}()
+
+	network, err := types.NetworkByBech32Hrp("tst")
+	require.NoError(t, err)
+	require.Equal(t, devnet, network)
+
+	network, err = types.NetworkByCoinType(123456)
+	require.NoError(t, err)
+	require.Equal(t, devnet, network)
+
+	// Built-in networks can't be replaced, nor their coin types reused
+	err = types.RegisterNetwork(types.Network{Name: types.NetworkShimmer.Name, CoinType: 123456, TokenSymbol: "X"})
+	require.ErrorIs(t, err, types.ErrInvalidNetwork)
+	err = types.RegisterNetwork(types.Network{Name: "shimmer-devnet", CoinType: types.CoinTypeSMR, Bech32Hrp: "sdev", TokenSymbol: "X"})
+	require.ErrorIs(t, err, types.ErrInvalidNetwork)
+	network, err = types.NetworkByCoinType(types.CoinTypeSMR)
+	require.NoError(t, err)
+	require.Equal(t, types.NetworkShimmer, network)
+
+	// Re-registering by name replaces the network
+	devnet.TokenSymbol = "DEV"
+	require.NoError(t, types.RegisterNetwork(devnet))
+	network, err = types.NetworkByName("wasp-devnet")
+	require.NoError(t, err)
+	require.Equal(t, "DEV", network.TokenSymbol)
+
+	err = types.RegisterNetwork(types.Network{Name: "clash", Bech32Hrp: "smr", TokenSymbol: "X"})
+	require.ErrorIs(t, err, types.ErrInvalidNetwork)
+
+	err = types.RegisterNetwork(types.Network{Name: "upper", Bech32Hrp: "ABC", TokenSymbol: "X"})
+	require.ErrorIs(t, err, types.ErrInvalidNetwork)
+
+	err = types.RegisterNetwork(types.Network{Bech32Hrp: "abc", TokenSymbol: "X"})
+	require.ErrorIs(t, err, types.ErrInvalidNetwork)
+
+	// Built-in networks stay registered
+	require.ErrorIs(t, types.UnregisterNetwork(types.NetworkShimmer.Name), types.ErrInvalidNetwork)
+	require.ErrorIs(t, types.UnregisterNetwork("unknown"), types.ErrUnknownNetwork)
+	hrp, err := types.ResolveBech32Hrp(types.CoinTypeSMR, "")
+	require.NoError(t, err)
+	require.Equal(t, "smr", hrp)
+}
+
+func TestNetworkFormatAmount(t *testing.T) {
+	require.Equal(t, "1.5 SMR", types.NetworkShimmer.FormatAmount(big.NewInt(1_500_000)))
+	require.Equal(t, "0.000001 SMR", types.NetworkShimmer.FormatAmount(big.NewInt(1)))
+	require.Equal(t, "0 SMR", types.NetworkShimmer.FormatAmount(nil))
+	require.Equal(t, "-42 IOTA", types.NetworkIOTA.FormatAmount(big.NewInt(-42_000_000)))
+	require.Equal(t, "1 ETH", types.NetworkEther.FormatAmount(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)))
+}
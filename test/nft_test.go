@@ -0,0 +1,95 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestIrc27Metadata(t *testing.T) {
+	metadata := types.NewIrc27Metadata("image/png", "https://example.com/nft.png", "Test NFT")
+	metadata.Royalties = map[string]float64{"rms1qp": 0.1}
+
+	encoded, err := metadata.Hex()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(encoded), "0x"))
+
+	decoded, err := types.ParseIrc27Metadata(encoded)
+	require.NoError(t, err)
+	require.Equal(t, metadata, *decoded)
+
+	metadata.Royalties["rms1qz"] = 0.95
+	_, err = metadata.Hex()
+	require.ErrorIs(t, err, types.ErrInvalidIrc27Metadata)
+
+	_, err = types.ParseIrc27Metadata(types.NewHexEncodedString([]byte(`{"standard":"IRC30"}`)))
+	require.ErrorIs(t, err, types.ErrInvalidIrc27Metadata)
+}
+
+func TestComputeOutputChainId(t *testing.T) {
+	outputId := types.OutputId("0x" + strings.Repeat("00", 34))
+	nftId, err := types.ComputeOutputChainId(outputId)
+	require.NoError(t, err)
+	require.Len(t, string(nftId), 66)
+	require.False(t, types.IsNullId(nftId))
+
+	_, err = types.ComputeOutputChainId("0x1234")
+	require.Error(t, err)
+}
+
+func TestNewNft(t *testing.T) {
+	metadata, err := types.NewIrc27Metadata("image/png", "https://example.com/nft.png", "Test NFT").Hex()
+	require.NoError(t, err)
+
+	outputId := types.OutputId("0x" + strings.Repeat("ab", 32) + "0000")
+	outputData := types.OutputData{
+		OutputId: outputId,
+		Output: types.OutputDataOutput{
+			"type":   6,
+			"amount": "100000",
+			"nftId":  "0x" + strings.Repeat("00", 32),
+			"unlockConditions": []any{
+				map[string]any{"type": 0, "address": map[string]any{"type": 0, "pubKeyHash": "0x01"}},
+			},
+			"features": []any{
+				map[string]any{"type": 3, "tag": "0x74616771"},
+			},
+			"immutableFeatures": []any{
+				map[string]any{"type": 1, "address": map[string]any{"type": 0, "pubKeyHash": "0x02"}},
+				map[string]any{"type": 2, "data": string(metadata)},
+			},
+		},
+	}
+
+	nft, err := types.NewNft(outputData)
+	require.NoError(t, err)
+
+	expectedId, err := types.ComputeOutputChainId(outputId)
+	require.NoError(t, err)
+	require.Equal(t, expectedId, nft.NftId)
+	require.Equal(t, "100000", nft.Amount)
+	require.Equal(t, types.HexEncodedString("0x74616771"), nft.Tag)
+	require.Equal(t, types.HexEncodedString("0x02"), nft.Issuer.PubKeyHash)
+	require.NotNil(t, nft.Irc27)
+	require.Equal(t, "Test NFT", nft.Irc27.Name)
+
+	// Serialized with the camelCase field names of the native library
+	serialized, err := json.Marshal(nft)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(serialized, &fields))
+	require.Equal(t, string(expectedId), fields["nftId"])
+	require.Equal(t, string(outputId), fields["outputId"])
+	require.Equal(t, "0x74616771", fields["tag"])
+	require.Contains(t, fields, "immutableMetadata")
+	require.Contains(t, fields, "irc27")
+	require.NotContains(t, fields, "sender")
+
+	outputData.Output["type"] = 3
+	_, err = types.NewNft(outputData)
+	require.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+
+	"github.com/iotaledger/wasp-wallet-sdk/config"
+	"github.com/iotaledger/wasp-wallet-sdk/hdwallet"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func strongholdProfile(name string, network string, snapshotPath string) config.Profile {
+	return config.Profile{
+		Name:        name,
+		Network:     network,
+		StoragePath: "./" + name + "-db",
+		SecretManager: config.SecretManagerConfig{
+			Type:           config.SecretManagerStronghold,
+			SnapshotPath:   snapshotPath,
+			PasswordSource: "env:TEST_WALLET_PASSWORD",
+		},
+		ClientOptions: &types.ClientOptions{Nodes: []any{"http://localhost:14265"}},
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	profiles := config.NewProfiles(filepath.Join(t.TempDir(), "profiles"))
+
+	list, err := profiles.List()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	_, err = profiles.Current()
+	require.ErrorIs(t, err, config.ErrNoCurrentProfile)
+
+	testnet := strongholdProfile("testnet", types.NetworkShimmer.Name, "./testnet.stronghold")
+	testnet.Bech32Hrp = types.NetworkShimmer.TestnetBech32Hrp
+	require.NoError(t, profiles.Add(testnet))
+	require.NoError(t, profiles.Add(config.Profile{
+		Name:          "devnet",
+		Network:       types.NetworkShimmer.Name,
+		StoragePath:   "./devnet-db",
+		SecretManager: config.SecretManagerConfig{Type: config.SecretManagerLedger, Emulator: true},
+		ClientOptions: &types.ClientOptions{Nodes: []any{"http://localhost:14265"}},
+	}))
+
+	require.ErrorIs(t, profiles.Add(testnet), config.ErrProfileExists)
+
+	list, err = profiles.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "devnet", list[0].Name)
+	require.Equal(t, "testnet", list[1].Name)
+
+	profile, err := profiles.Get("testnet")
+	require.NoError(t, err)
+	require.Equal(t, testnet, *profile)
+
+	hrp, err := profile.ResolvedBech32Hrp()
+	require.NoError(t, err)
+	require.Equal(t, types.NetworkShimmer.TestnetBech32Hrp, hrp)
+
+	require.NoError(t, profiles.Switch("testnet"))
+	current, err := profiles.Current()
+	require.NoError(t, err)
+	require.Equal(t, "testnet", current.Name)
+
+	require.ErrorIs(t, profiles.Switch("mainnet"), config.ErrProfileNotFound)
+
+	// Removing the current profile unselects it
+	require.NoError(t, profiles.Remove("testnet"))
+	_, err = profiles.Current()
+	require.ErrorIs(t, err, config.ErrNoCurrentProfile)
+	_, err = profiles.Get("testnet")
+	require.ErrorIs(t, err, config.ErrProfileNotFound)
+}
+
+func TestProfileValidation(t *testing.T) {
+	profiles := config.NewProfiles(t.TempDir())
+
+	require.ErrorIs(t, profiles.Add(strongholdProfile("../escape", types.NetworkShimmer.Name, "./wallet.stronghold")), config.ErrInvalidProfileName)
+
+	err := profiles.Add(strongholdProfile("unknown", "", "./wallet.stronghold"))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	require.ErrorContains(t, err, "network: required")
+	require.NotContains(t, err.Error(), "coinType")
+
+	err = profiles.Add(strongholdProfile("unknown", "nonexistent", "./wallet.stronghold"))
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+
+	// Nodes and the password source may come from flags and prompts, but opening the profile requires them
+	incomplete := strongholdProfile("incomplete", types.NetworkShimmer.Name, "./wallet.stronghold")
+	incomplete.SecretManager.PasswordSource = ""
+	incomplete.ClientOptions = nil
+	require.NoError(t, profiles.Add(incomplete))
+
+	_, err = profiles.OpenProfile(nil, "incomplete")
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	require.ErrorContains(t, err, "clientOptions.nodes")
+	require.ErrorContains(t, err, "secretManager.passwordSource")
+
+	// Secrets are rejected in profile files as in config files
+	require.NoError(t, os.WriteFile(filepath.Join(profiles.Dir(), "inline.yaml"), []byte(`
+network: shimmer
+storagePath: ./wallet-db
+clientOptions:
+  nodes: ["http://localhost:14265"]
+secretManager:
+  type: mnemonic
+  mnemonic: "giant dynamic museum toddler six deny defense ostrich bomb access mercy blood"
+`), 0o600))
+
+	_, err = profiles.Get("inline")
+	require.ErrorIs(t, err, config.ErrInlineSecret)
+
+}
+
+func TestProfileStrongholdCoinType(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "wallet.stronghold")
+	profiles := config.NewProfiles(filepath.Join(dir, "profiles"))
+
+	require.NoError(t, profiles.Add(strongholdProfile("shimmer", types.NetworkShimmer.Name, snapshotPath)))
+
+	// Profiles of the same coin type may share the snapshot, profiles of another coin type may not
+	require.NoError(t, profiles.Add(strongholdProfile("shimmer-copy", types.NetworkShimmer.Name, snapshotPath)))
+	require.ErrorIs(t, profiles.Add(strongholdProfile("iota", types.NetworkIOTA.Name, snapshotPath)), config.ErrCoinTypeMismatch)
+
+	secretManager, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+	defer secretManager.Destroy()
+
+	// The first address is recorded on first use and survives moving the snapshot
+	profile, err := profiles.Get("shimmer")
+	require.NoError(t, err)
+	require.NoError(t, profiles.CheckStronghold(profile, secretManager, types.CoinTypeSMR))
+
+	profile, err = profiles.Get("shimmer")
+	require.NoError(t, err)
+	require.Equal(t, "smr1qzxfxxdu6hucu5lkrw5glzewr6sjf5veemmv2kjj0drnshhyglj27tf5up8", profile.Address)
+
+	profile.SecretManager.SnapshotPath = filepath.Join(dir, "moved.stronghold")
+	require.NoError(t, profiles.CheckStronghold(profile, secretManager, types.CoinTypeSMR))
+
+	// Another coin type or another snapshot derive another first address
+	require.ErrorIs(t, profiles.CheckStronghold(profile, secretManager, types.CoinTypeIOTA), config.ErrAddressMismatch)
+
+	otherSecretManager, err := hdwallet.NewMnemonicSecretManager(memguard.NewEnclave([]byte(iotaSDKTestMnemonic)))
+	require.NoError(t, err)
+	defer otherSecretManager.Destroy()
+	require.ErrorIs(t, profiles.CheckStronghold(profile, otherSecretManager, types.CoinTypeSMR), config.ErrAddressMismatch)
+}
+
+func TestProfilePrivateNetwork(t *testing.T) {
+	profiles := config.NewProfiles(t.TempDir())
+
+	devnet := strongholdProfile("devnet", "profile-devnet", "./devnet.stronghold")
+	devnet.CoinType = 123456
+	devnet.Bech32Hrp = "pdev"
+	require.NoError(t, profiles.Add(devnet))
+	defer types.UnregisterNetwork(devnet.Network)
+
+	// The network is registered when the profile is loaded
+	require.NoError(t, types.UnregisterNetwork(devnet.Network))
+	profile, err := profiles.Get("devnet")
+	require.NoError(t, err)
+
+	network, err := types.NetworkByName(devnet.Network)
+	require.NoError(t, err)
+	require.Equal(t, types.CoinType(123456), network.CoinType)
+	require.Equal(t, "pdev", network.Bech32Hrp)
+
+	coinType, err := profile.ResolvedCoinType()
+	require.NoError(t, err)
+	require.Equal(t, types.CoinType(123456), coinType)
+
+	// The coin type of a known network can't be changed
+	shimmer := strongholdProfile("shimmer", types.NetworkShimmer.Name, "./shimmer.stronghold")
+	shimmer.CoinType = types.CoinTypeIOTA
+	require.ErrorIs(t, profiles.Add(shimmer), config.ErrInvalidConfig)
+}
+
+func TestOpenProfile(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "wallet.stronghold")
+	profiles := config.NewProfiles(filepath.Join(dir, "profiles"))
+	t.Setenv("TEST_WALLET_PASSWORD", "password")
+
+	profile := strongholdProfile("testnet", types.NetworkShimmer.Name, snapshotPath)
+	profile.Bech32Hrp = "rms"
+	require.NoError(t, profiles.Add(profile))
+
+	// The snapshot path in the temporary directory of the test is normalized.
+	// The first attempt fails, e.g. with a wrong password, the second one opens the wallet and records its first address.
+	request := `{"clientOptions": {"nodes": ["http://localhost:14265"]}, "coinType": 4219, "storagePath": "./testnet-db",
+    "secretManager": {"stronghold": {"password": "[REDACTED]", "snapshotPath": "[TMPDIR]/001/wallet.stronghold"}}}`
+	generateAddress := `{"call": "call_secret_manager_method", "handle": 3,
+    "request": {"name": "generateEd25519Addresses", "data": {"options": {"bech32Hrp": "rms", "coinType": 4219, "range": {"start": 0, "end": 1}}}},
+    "response": {"type": "generatedEd25519Addresses", "payload": ["rms1qzxfxxdu6hucu5lkrw5glzewr6sjf5veemmv2kjj0drnshhyglj27lwlx67"]}}`
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_wallet", "request": `+request+`, "error": "invalid stronghold password"},
+    {"call": "create_wallet", "request": `+request+`, "result": 1},
+    {"call": "get_client_from_wallet", "handle": 1, "result": 2},
+    {"call": "get_secret_manager_from_wallet", "handle": 1, "result": 3},
+    `+generateAddress+`
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	_, err = profiles.OpenProfile(sdk, "testnet")
+	require.ErrorContains(t, err, "invalid stronghold password")
+	stored, err := profiles.Get("testnet")
+	require.NoError(t, err)
+	require.Empty(t, stored.Address)
+
+	wallet, err := profiles.OpenProfile(sdk, "testnet")
+	require.NoError(t, err)
+	defer wallet.Destroy()
+
+	stored, err = profiles.Get("testnet")
+	require.NoError(t, err)
+	require.Equal(t, "rms1qzxfxxdu6hucu5lkrw5glzewr6sjf5veemmv2kjj0drnshhyglj27lwlx67", stored.Address)
+
+	hrp, err := wallet.Bech32Hrp()
+	require.NoError(t, err)
+	require.Equal(t, "rms", hrp)
+}
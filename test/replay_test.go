@@ -0,0 +1,118 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/require"
+
+	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func writeRecording(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestReplaySecretManager(t *testing.T) {
+	// Only the mnemonic is redacted, the essence is kept
+	path := writeRecording(t, `{
+  "calls": [
+    {
+      "call": "create_secret_manager",
+      "request": {"mnemonic": "[REDACTED]"},
+      "result": 1
+    },
+    {
+      "call": "call_secret_manager_method",
+      "handle": 1,
+      "request": {"data": {"chain": {"account": 0, "addressIndex": 0, "change": 0, "coinType": 4219}, "message": "0xcf30a3824d6b2d3b25ec63aa97733e4fc4dd99e6d38c97093a0abd21f5e9016c"}, "name": "signEd25519"},
+      "response": {"type": "ed25519Signature", "payload": {"type": 0, "publicKey": "0x1234", "signature": "0x5678"}}
+    }
+  ]
+}`)
+
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(path)
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	secretManager, err := wasp_wallet_sdk.NewMnemonicSecretManager(sdk, memguard.NewEnclave([]byte(Mnemonic)))
+	require.NoError(t, err)
+
+	// Another essence doesn't match the recording
+	_, err = secretManager.SignTransactionEssence("0x0000000000000000000000000000000000000000000000000000000000000000", wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.ErrorContains(t, err, wasp_wallet_sdk.ErrReplayMismatch.Error())
+
+	signature, err := secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.NoError(t, err)
+	require.Equal(t, "0x1234", signature.PublicKey)
+	require.Equal(t, "0x5678", signature.Signature)
+
+	// Calls beyond the recording fail
+	_, err = secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.ErrorContains(t, err, wasp_wallet_sdk.ErrReplayMismatch.Error())
+	require.ErrorContains(t, err, "no more calls")
+}
+
+func TestReplayMismatch(t *testing.T) {
+	path := writeRecording(t, `{
+  "calls": [
+    {
+      "call": "call_utils_method",
+      "request": {"data": {"address": "rms1qpszqzadsym6wpppd6z037dvlejmjuke7s24hm95s9fg9vpua7vluehe53e"}, "name": "parseBech32Address"},
+      "response": {"type": "address", "payload": {"type": 0, "pubKeyHash": "0x60200bad8137a704216e84f8f9acfe65b972d9f4155becb4815282b03cef99fe"}}
+    },
+    {
+      "call": "call_utils_method",
+      "request": {"data": {"address": "not an address"}, "name": "parseBech32Address"},
+      "error": "invalid address"
+    }
+  ]
+}`)
+
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(path)
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	_, err = sdk.Utils().ParseBech32Address("rms1qpszqzadsym6wpppd6z037dvlejmjuke7s24hm95s9fg9vpua7vluehe53f")
+	require.ErrorContains(t, err, wasp_wallet_sdk.ErrReplayMismatch.Error())
+	require.ErrorContains(t, err, `-     "address": "rms1qpszqzadsym6wpppd6z037dvlejmjuke7s24hm95s9fg9vpua7vluehe53e"`)
+	require.ErrorContains(t, err, `+     "address": "rms1qpszqzadsym6wpppd6z037dvlejmjuke7s24hm95s9fg9vpua7vluehe53f"`)
+
+	// A mismatch doesn't consume the recorded call
+	address, err := sdk.Utils().ParseBech32Address("rms1qpszqzadsym6wpppd6z037dvlejmjuke7s24hm95s9fg9vpua7vluehe53e")
+	require.NoError(t, err)
+	require.Equal(t, types.AddressType(0), address.Type)
+
+	// Recorded errors are replayed
+	_, err = sdk.Utils().ParseBech32Address("not an address")
+	require.EqualError(t, err, "invalid address")
+}
+
+func TestReplayNormalizesPaths(t *testing.T) {
+	// Temporary and working directories differ between runs and machines
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{
+  "calls": [
+    {"call": "create_secret_manager", "request": {"stronghold": {"password": "[REDACTED]", "snapshotPath": "[TMPDIR]/002/wallet.stronghold"}}, "result": 1},
+    {"call": "create_secret_manager", "request": {"stronghold": {"password": "[REDACTED]", "snapshotPath": "[WORKDIR]/testdb/wallet.stronghold"}}, "result": 2}
+  ]
+}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	password := memguard.NewEnclave([]byte("password"))
+
+	_, err = wasp_wallet_sdk.NewStrongholdSecretManager(sdk, password, filepath.Join(t.TempDir(), "wallet.stronghold"))
+	require.NoError(t, err)
+
+	workDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	_, err = wasp_wallet_sdk.NewStrongholdSecretManager(sdk, password, filepath.Join(workDir, "testdb", "wallet.stronghold"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+func TestTransactionParsing(t *testing.T) {
+	var transaction types.Transaction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"blockId": "0x01",
+		"inclusionState": "Confirmed",
+		"incoming": false,
+		"networkId": "1856588631910923207",
+		"note": "payout",
+		"timestamp": "1700000000123",
+		"transactionId": "0x02",
+		"payload": {
+			"type": 6,
+			"essence": {
+				"type": 1,
+				"networkId": "1856588631910923207",
+				"inputs": [{"type": 0, "transactionId": "0x03", "transactionOutputIndex": 1}],
+				"inputsCommitment": "0x04",
+				"outputs": [
+					{"type": 3, "amount": "1000000", "unlockConditions": [{"type": 0, "address": {"type": 0, "pubKeyHash": "0x05"}}]}
+				]
+			},
+			"unlocks": [{"type": 0, "signature": {"type": 0, "publicKey": "0x06", "signature": "0x07"}}]
+		},
+		"inputs": [
+			{"metadata": {"blockId": "0x08", "transactionId": "0x03", "outputIndex": 1, "isSpent": true}, "output": {"type": 3, "amount": "1000000", "unlockConditions": []}}
+		]
+	}`), &transaction))
+
+	require.Equal(t, types.InclusionStateConfirmed, transaction.InclusionState)
+	require.Equal(t, "payout", transaction.Note)
+	require.Len(t, transaction.Outputs(), 1)
+	require.Equal(t, "1000000", transaction.Outputs()[0].Amount)
+	require.Equal(t, uint16(1), transaction.Payload.Essence.Inputs[0].TransactionOutputIndex)
+	require.Len(t, transaction.Inputs, 1)
+	require.True(t, transaction.Inputs[0].Metadata.IsSpent)
+
+	timestamp, err := transaction.Time()
+	require.NoError(t, err)
+	require.Equal(t, time.UnixMilli(1700000000123), timestamp)
+
+	transaction.Timestamp = "yesterday"
+	_, err = transaction.Time()
+	require.Error(t, err)
+}
@@ -3,11 +3,14 @@
 package test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	wasp_wallet_sdk "github.com/iotaledger/wasp-wallet-sdk"
 	"github.com/iotaledger/wasp-wallet-sdk/types"
 
+	"github.com/awnumar/memguard"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,11 +54,9 @@ func TestWalletCallAccountMethod(t *testing.T) {
 		StoragePath: "./testdb/ledger",
 		CoinType:    types.CoinTypeSMR,
 	})
-	wallet.ListenToUpdates()
-	defer wallet.Destroy()
-
 	require.NoError(t, err)
 	require.NotNil(t, wallet)
+	defer wallet.Destroy()
 
 	result, err := wallet.CallAccountMethod(0, types.NewGenerateAccountEd25519Addresses(1, types.GenerateAddressOptions{
 		LedgerNanoPrompt: true,
@@ -76,18 +77,17 @@ func TestWalletEventsWithLedger(t *testing.T) {
 		StoragePath: "./testdb/ledger",
 		CoinType:    types.CoinTypeSMR,
 	})
-	wallet.ListenToUpdates()
-
-	defer wallet.Destroy()
-
 	require.NoError(t, err)
 	require.NotNil(t, wallet)
+	defer wallet.Destroy()
+
+	require.NoError(t, wallet.Listen([]types.WalletEventType{types.WalletEventTypeLedgerAddressGeneration}, func(types.WalletEvent) {}))
 
 	status, err := wallet.GetLedgerStatus()
 	require.NoError(t, err)
 	require.NotNil(t, status)
 
-	address, err := wallet.GenerateEd25519Address(0, 0, "smr", nil)
+	address, err := wallet.GenerateEd25519Address(0, 0, "smr", types.CoinTypeSMR, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, address)
 
@@ -118,7 +118,7 @@ func TestWalletLedger(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, status)
 
-	address, err := wallet.GenerateEd25519Address(0, 0, "smr", nil)
+	address, err := wallet.GenerateEd25519Address(0, 0, "smr", types.CoinTypeSMR, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, address)
 
@@ -147,11 +147,11 @@ func TestWalletStronghold(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, wallet)
 
-	res, err := wallet.StoreMnemonic(Mnemonic)
+	res, err := wallet.StoreMnemonic(memguard.NewEnclave([]byte(Mnemonic)))
 	require.NoError(t, err)
 	require.NotEmpty(t, res)
 
-	address, err := wallet.GenerateEd25519Address(0, 0, "smr", nil)
+	address, err := wallet.GenerateEd25519Address(0, 0, "smr", types.CoinTypeSMR, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, address)
 
@@ -160,3 +160,132 @@ func TestWalletStronghold(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, signedEssence)
 }
+
+func TestWalletAccounts(t *testing.T) {
+	sdk := GetOrInitTest(t)
+
+	wallet, err := sdk.CreateWallet(types.WalletOptions{
+		SecretManager: types.MnemonicSecretManager{
+			Mnemonic: Mnemonic,
+		},
+		ClientOptions: &types.ClientOptions{},
+		StoragePath:   "./testdb/accounts",
+		CoinType:      types.CoinTypeSMR,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, wallet)
+	defer wallet.Destroy()
+
+	account, err := wallet.CreateAccount(context.Background(), types.CreateAccountOptions{
+		Alias: "alice",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "alice", account.Alias)
+	require.Equal(t, types.CoinTypeSMR, account.CoinType)
+	require.NotEmpty(t, account.PublicAddresses)
+	require.True(t, strings.HasPrefix(account.PublicAddresses[0].Address, "smr1"))
+
+	indexes, err := wallet.GetAccountIndexes()
+	require.NoError(t, err)
+	require.Contains(t, indexes, account.Index)
+
+	accounts, err := wallet.GetAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, len(indexes))
+
+	byAlias, err := wallet.GetAccount(types.AccountAliasIdentifier("alice"))
+	require.NoError(t, err)
+	require.Equal(t, account.Index, byAlias.Index)
+
+	byIndex, err := wallet.GetAccount(types.AccountIndexIdentifier(account.Index))
+	require.NoError(t, err)
+	require.Equal(t, "alice", byIndex.Alias)
+
+	removed, err := wallet.RemoveLatestAccount()
+	require.NoError(t, err)
+	require.True(t, removed)
+}
+
+func TestWalletCloseDestroysDerivedHandles(t *testing.T) {
+	sdk := GetOrInitTest(t)
+	liveHandles := len(sdk.LiveHandles())
+
+	wallet, err := sdk.CreateWallet(types.WalletOptions{
+		SecretManager: types.MnemonicSecretManager{
+			Mnemonic: Mnemonic,
+		},
+		ClientOptions: &types.ClientOptions{},
+		StoragePath:   "./testdb/lifecycle",
+		CoinType:      types.CoinTypeSMR,
+	})
+	require.NoError(t, err)
+
+	// The wallet, its client and its secret manager
+	handles := sdk.LiveHandles()
+	require.Len(t, handles, liveHandles+3)
+	require.Equal(t, wasp_wallet_sdk.HandleKindWallet, handles[liveHandles].Kind)
+	require.Equal(t, wasp_wallet_sdk.HandleKindWallet, handles[liveHandles+1].ParentKind)
+	require.Equal(t, wasp_wallet_sdk.HandleKindWallet, handles[liveHandles+2].ParentKind)
+
+	require.NoError(t, wallet.Close())
+	require.Len(t, sdk.LiveHandles(), liveHandles)
+}
+
+func TestWalletSecretManager(t *testing.T) {
+	sdk := GetOrInitTest(t)
+
+	wallet, err := sdk.CreateWallet(types.WalletOptions{
+		SecretManager: types.MnemonicSecretManager{
+			Mnemonic: Mnemonic,
+		},
+		ClientOptions: &types.ClientOptions{},
+		StoragePath:   "./testdb/signer",
+		CoinType:      types.CoinTypeSMR,
+	})
+	require.NoError(t, err)
+	defer wallet.Destroy()
+
+	secretManager, err := wallet.SecretManager()
+	require.NoError(t, err)
+
+	// Both sign with the keys of the wallet
+	var addresses []string
+	for _, signer := range []wasp_wallet_sdk.Signer{wallet, secretManager} {
+		address, err := signer.GenerateEd25519Address(0, 0, "", types.CoinTypeSMR, nil)
+		require.NoError(t, err)
+		addresses = append(addresses, address)
+	}
+	require.Equal(t, addresses[0], addresses[1])
+
+	// The wallet keeps its secret manager alive
+	require.NoError(t, secretManager.Close())
+	_, err = wallet.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.NoError(t, err)
+
+	// And the secret manager outlives the wallet until it's closed
+	secretManager, err = wallet.SecretManager()
+	require.NoError(t, err)
+	require.NoError(t, wallet.Close())
+
+	_, err = secretManager.SignTransactionEssence(SignMessageFromEssenceHex, wasp_wallet_sdk.BuildBip44Chain(types.CoinTypeSMR, 0, 0))
+	require.NoError(t, err)
+	require.NoError(t, secretManager.Close())
+
+	_, err = wallet.SecretManager()
+	require.ErrorIs(t, err, wasp_wallet_sdk.ErrHandleClosed)
+}
+
+func TestNewWalletCoinType(t *testing.T) {
+	sdk, err := wasp_wallet_sdk.NewReplaySDK(writeRecording(t, `{"calls": []}`))
+	require.NoError(t, err)
+	defer sdk.Destroy()
+
+	// Wallets wrapping existing handles know their coin type as well
+	wallet := wasp_wallet_sdk.NewWallet(sdk, 1, 0, 0, types.WalletOptions{CoinType: types.CoinTypeIOTA})
+	defer wallet.Destroy()
+	require.Equal(t, types.CoinTypeIOTA, wallet.CoinType())
+
+	hrp, err := wallet.Bech32Hrp()
+	require.NoError(t, err)
+	require.Equal(t, types.NetworkIOTA.Bech32Hrp, hrp)
+}
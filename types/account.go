@@ -1,5 +1,10 @@
 package types
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
 type BaseCallAccountMethod[T BaseCallAccountMethodWrap[any]] struct {
 	AccountId uint32 `json:"accountId"`
 	Method    T      `json:"method"`
@@ -10,6 +15,10 @@ type BaseCallAccountMethodWrap[T any] struct {
 	Data T      `json:"data"`
 }
 
+func (m BaseCallAccountMethod[T]) MethodName() string {
+	return BaseCallAccountMethodWrap[any](m.Method).Name
+}
+
 type GenerateAccountEd25519Addresses struct {
 	Amount  uint32                 `json:"amount"`
 	Options GenerateAddressOptions `json:"options"`
@@ -24,3 +33,148 @@ func NewGenerateAccountEd25519Addresses(amount uint32, options GenerateAddressOp
 		},
 	}
 }
+
+// AccountIdentifier is either the index or the alias of an account
+type AccountIdentifier struct {
+	index *uint32
+	alias string
+}
+
+func AccountIndexIdentifier(index uint32) AccountIdentifier {
+	return AccountIdentifier{index: &index}
+}
+
+func AccountAliasIdentifier(alias string) AccountIdentifier {
+	return AccountIdentifier{alias: alias}
+}
+
+func (a AccountIdentifier) MarshalJSON() ([]byte, error) {
+	if a.index != nil {
+		return json.Marshal(*a.index)
+	}
+
+	return json.Marshal(a.alias)
+}
+
+func (a AccountIdentifier) String() string {
+	if a.index != nil {
+		return strconv.FormatUint(uint64(*a.index), 10)
+	}
+
+	return a.alias
+}
+
+// Options for account creation
+type CreateAccountOptions struct {
+	// Alias of the account, defaults to the account index
+	Alias string `json:"alias,omitempty" yaml:"alias,omitempty" mapstructure:"alias,omitempty"`
+
+	// Bech32Hrp of the account addresses, defaults to the HRP of the wallets coin type
+	Bech32Hrp string `json:"bech32Hrp,omitempty" yaml:"bech32Hrp,omitempty" mapstructure:"bech32Hrp,omitempty"`
+
+	// Addresses to add to the account instead of generating the first one
+	Addresses []AccountAddress `json:"addresses,omitempty" yaml:"addresses,omitempty" mapstructure:"addresses,omitempty"`
+}
+
+// An Address of the Account
+type AccountAddress struct {
+	// Address corresponds to the JSON schema field "address".
+	Address string `json:"address" yaml:"address" mapstructure:"address"`
+
+	// Internal corresponds to the JSON schema field "internal".
+	Internal bool `json:"internal" yaml:"internal" mapstructure:"internal"`
+
+	// KeyIndex corresponds to the JSON schema field "keyIndex".
+	KeyIndex uint32 `json:"keyIndex" yaml:"keyIndex" mapstructure:"keyIndex"`
+
+	// Used corresponds to the JSON schema field "used".
+	Used bool `json:"used" yaml:"used" mapstructure:"used"`
+}
+
+// Address with unspent outputs
+type AddressWithUnspentOutputs struct {
+	// Address corresponds to the JSON schema field "address".
+	Address string `json:"address" yaml:"address" mapstructure:"address"`
+
+	// Internal corresponds to the JSON schema field "internal".
+	Internal bool `json:"internal" yaml:"internal" mapstructure:"internal"`
+
+	// KeyIndex corresponds to the JSON schema field "keyIndex".
+	KeyIndex uint32 `json:"keyIndex" yaml:"keyIndex" mapstructure:"keyIndex"`
+
+	// Amount corresponds to the JSON schema field "amount".
+	Amount string `json:"amount" yaml:"amount" mapstructure:"amount"`
+
+	// OutputIds corresponds to the JSON schema field "outputIds".
+	OutputIds []string `json:"outputIds" yaml:"outputIds" mapstructure:"outputIds"`
+}
+
+// The identifier of an Output
+type OutputId string
+
+// Associated account address
+type OutputDataAddress map[string]interface{}
+
+// The actual Output
+type OutputDataOutput map[string]interface{}
+
+// An output with metadata
+type OutputData struct {
+	// Associated account address
+	Address OutputDataAddress `json:"address" yaml:"address" mapstructure:"address"`
+
+	// BIP44 path, only set for outputs of ed25519 addresses of the account
+	Chain *Bip44Chain `json:"chain,omitempty" yaml:"chain,omitempty" mapstructure:"chain,omitempty"`
+
+	// If an output is spent
+	IsSpent bool `json:"isSpent" yaml:"isSpent" mapstructure:"isSpent"`
+
+	// The metadata of the output
+	Metadata IOutputMetadataResponse `json:"metadata" yaml:"metadata" mapstructure:"metadata"`
+
+	// Network ID
+	NetworkId string `json:"networkId" yaml:"networkId" mapstructure:"networkId"`
+
+	// The actual Output
+	Output OutputDataOutput `json:"output" yaml:"output" mapstructure:"output"`
+
+	// The identifier of an Output
+	OutputId OutputId `json:"outputId" yaml:"outputId" mapstructure:"outputId"`
+
+	// Remainder
+	Remainder bool `json:"remainder" yaml:"remainder" mapstructure:"remainder"`
+}
+
+// AccountDetails The account object as returned by the wallet
+type AccountDetails struct {
+	// The account index which will be used in the BIP44 path
+	Index uint32 `json:"index" yaml:"index" mapstructure:"index"`
+
+	// The used coin type
+	CoinType CoinType `json:"coinType" yaml:"coinType" mapstructure:"coinType"`
+
+	// The account alias
+	Alias string `json:"alias" yaml:"alias" mapstructure:"alias"`
+
+	// PublicAddresses corresponds to the JSON schema field "publicAddresses".
+	PublicAddresses []AccountAddress `json:"publicAddresses" yaml:"publicAddresses" mapstructure:"publicAddresses"`
+
+	// InternalAddresses corresponds to the JSON schema field "internalAddresses".
+	InternalAddresses []AccountAddress `json:"internalAddresses" yaml:"internalAddresses" mapstructure:"internalAddresses"`
+
+	// AddressesWithUnspentOutputs corresponds to the JSON schema field
+	// "addressesWithUnspentOutputs".
+	AddressesWithUnspentOutputs []AddressWithUnspentOutputs `json:"addressesWithUnspentOutputs" yaml:"addressesWithUnspentOutputs" mapstructure:"addressesWithUnspentOutputs"`
+
+	// Outputs corresponds to the JSON schema field "outputs".
+	Outputs map[OutputId]OutputData `json:"outputs" yaml:"outputs" mapstructure:"outputs"`
+
+	// Output IDs of unspent outputs that are currently used as input for transactions
+	LockedOutputs []OutputId `json:"lockedOutputs" yaml:"lockedOutputs" mapstructure:"lockedOutputs"`
+
+	// UnspentOutputs corresponds to the JSON schema field "unspentOutputs".
+	UnspentOutputs map[OutputId]OutputData `json:"unspentOutputs" yaml:"unspentOutputs" mapstructure:"unspentOutputs"`
+
+	// Transaction IDs of pending transactions
+	PendingTransactions []string `json:"pendingTransactions" yaml:"pendingTransactions" mapstructure:"pendingTransactions"`
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Options for the alias output creation
+type AliasOutputParams struct {
+	// Bech32 encoded address which will control the alias. Default will use the
+	// first address of the account
+	Address string `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Immutable metadata of the alias
+	ImmutableMetadata HexEncodedString `json:"immutableMetadata,omitempty" yaml:"immutableMetadata,omitempty" mapstructure:"immutableMetadata,omitempty"`
+
+	// Metadata of the alias
+	Metadata HexEncodedString `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+
+	// State metadata of the alias, can only be changed by the state controller
+	StateMetadata HexEncodedString `json:"stateMetadata,omitempty" yaml:"stateMetadata,omitempty" mapstructure:"stateMetadata,omitempty"`
+}
+
+// Alias is an alias output owned by an account, e.g. the anchor of a Wasp chain
+type Alias struct {
+	AliasId  HexEncodedString
+	OutputId OutputId
+	Amount   string
+
+	// StateIndex is incremented by each state transition
+	StateIndex     uint32
+	StateMetadata  HexEncodedString
+	FoundryCounter uint32
+
+	StateController *Address
+	Governor        *Address
+
+	Issuer *Address
+	Sender *Address
+
+	Metadata          HexEncodedString
+	ImmutableMetadata HexEncodedString
+}
+
+// NewAlias reads the alias of an unspent alias output
+func NewAlias(outputData OutputData) (*Alias, error) {
+	output, err := ParseOutput(outputData.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Type != OutputTypeAlias {
+		return nil, fmt.Errorf("output %s is not an alias output", outputData.OutputId)
+	}
+
+	alias := &Alias{
+		AliasId:        output.AliasId,
+		OutputId:       outputData.OutputId,
+		Amount:         output.Amount,
+		StateIndex:     output.StateIndex,
+		StateMetadata:  output.StateMetadata,
+		FoundryCounter: output.FoundryCounter,
+	}
+
+	// The alias ID is only set in outputs after the creating output was spent
+	if IsNullId(alias.AliasId) {
+		if alias.AliasId, err = ComputeOutputChainId(outputData.OutputId); err != nil {
+			return nil, err
+		}
+	}
+
+	if unlockCondition := output.UnlockCondition(UnlockConditionTypeStateControllerAddress); unlockCondition != nil {
+		alias.StateController = unlockCondition.Address
+	}
+	if unlockCondition := output.UnlockCondition(UnlockConditionTypeGovernorAddress); unlockCondition != nil {
+		alias.Governor = unlockCondition.Address
+	}
+	if feature := output.ImmutableFeature(FeatureTypeIssuer); feature != nil {
+		alias.Issuer = feature.Address
+	}
+	if feature := output.ImmutableFeature(FeatureTypeMetadata); feature != nil {
+		alias.ImmutableMetadata = feature.Data
+	}
+	if feature := output.Feature(FeatureTypeSender); feature != nil {
+		alias.Sender = feature.Address
+	}
+	if feature := output.Feature(FeatureTypeMetadata); feature != nil {
+		alias.Metadata = feature.Data
+	}
+
+	return alias, nil
+}
+
+// NewAliasStateTransition returns the next state of an alias output with the state index incremented.
+// The transition has to be unlocked by the state controller.
+func NewAliasStateTransition(outputData OutputData, stateMetadata HexEncodedString) (OutputDataOutput, error) {
+	alias, output, err := nextAliasOutput(outputData)
+	if err != nil {
+		return nil, err
+	}
+
+	output["stateIndex"] = alias.StateIndex + 1
+	if stateMetadata == "" {
+		delete(output, "stateMetadata")
+	} else {
+		output["stateMetadata"] = stateMetadata
+	}
+
+	return output, nil
+}
+
+// NewAliasGovernanceTransition returns the alias output with replaced controllers, the state index stays the same.
+// The transition has to be unlocked by the current governor. Nil arguments keep the current value.
+func NewAliasGovernanceTransition(outputData OutputData, stateController *Address, governor *Address, metadata *HexEncodedString) (OutputDataOutput, error) {
+	_, output, err := nextAliasOutput(outputData)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockConditions, _ := output["unlockConditions"].([]any)
+	for _, unlockCondition := range unlockConditions {
+		unlockConditionMap, ok := unlockCondition.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		switch typeOf[UnlockConditionType](unlockConditionMap) {
+		case UnlockConditionTypeStateControllerAddress:
+			if stateController != nil {
+				unlockConditionMap["address"] = stateController
+			}
+		case UnlockConditionTypeGovernorAddress:
+			if governor != nil {
+				unlockConditionMap["address"] = governor
+			}
+		}
+	}
+
+	if metadata != nil {
+		features, _ := output["features"].([]any)
+		updatedFeatures := make([]any, 0, len(features)+1)
+		for _, feature := range features {
+			if featureTypeOf(feature) == FeatureTypeMetadata {
+				continue
+			}
+			updatedFeatures = append(updatedFeatures, feature)
+		}
+
+		if *metadata != "" {
+			updatedFeatures = append(updatedFeatures, Feature{Type: FeatureTypeMetadata, Data: *metadata})
+		}
+
+		// Features have to be sorted by type
+		sort.SliceStable(updatedFeatures, func(a, b int) bool {
+			return featureTypeOf(updatedFeatures[a]) < featureTypeOf(updatedFeatures[b])
+		})
+
+		output["features"] = updatedFeatures
+	}
+
+	return output, nil
+}
+
+// nextAliasOutput copies the alias output and sets its alias ID, which is null in newly created aliases
+func nextAliasOutput(outputData OutputData) (*Alias, OutputDataOutput, error) {
+	alias, err := NewAlias(outputData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialized, err := json.Marshal(outputData.Output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(serialized))
+	decoder.UseNumber()
+
+	output := make(OutputDataOutput)
+	if err := decoder.Decode(&output); err != nil {
+		return nil, nil, err
+	}
+
+	output["aliasId"] = alias.AliasId
+
+	return alias, output, nil
+}
+
+func featureTypeOf(feature any) FeatureType {
+	switch feature := feature.(type) {
+	case Feature:
+		return feature.Type
+	case map[string]any:
+		return typeOf[FeatureType](feature)
+	default:
+		return 0
+	}
+}
+
+// typeOf decodes the type of a serialized output, feature or unlock condition, 0 if it has none
+func typeOf[T ~uint8](value map[string]any) T {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+
+	var typed struct {
+		Type T `json:"type"`
+	}
+	if err := json.Unmarshal(serialized, &typed); err != nil {
+		return 0
+	}
+
+	return typed.Type
+}
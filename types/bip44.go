@@ -0,0 +1,151 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Bip44HardenedOffset is added to an index to mark it as hardened.
+	// Every index of a Bip44Chain has to be smaller than this value.
+	Bip44HardenedOffset uint32 = 1 << 31
+
+	// Bip44ChangeExternal is the change index of public (receiving) addresses.
+	Bip44ChangeExternal uint32 = 0
+
+	// Bip44ChangeInternal is the change index of internal (remainder) addresses.
+	Bip44ChangeInternal uint32 = 1
+)
+
+var ErrInvalidBip44Path = errors.New("invalid BIP44 path")
+
+// ParseBip44Path parses a path in the form of m/44'/4219'/0'/0'/5'.
+// Indices may be marked as hardened with ', h or H; the marker is optional, as the SDK always derives hardened keys.
+func ParseBip44Path(path string) (Bip44Chain, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if len(segments) != 6 || segments[0] != "m" {
+		return Bip44Chain{}, fmt.Errorf("%w: %q must have the form m/purpose'/coin_type'/account'/change'/address_index'", ErrInvalidBip44Path, path)
+	}
+
+	indices := make([]uint32, 0, 5)
+	for _, segment := range segments[1:] {
+		index, err := parseBip44Index(segment)
+		if err != nil {
+			return Bip44Chain{}, fmt.Errorf("%w: %q: %v", ErrInvalidBip44Path, path, err)
+		}
+
+		indices = append(indices, index)
+	}
+
+	if indices[0] != uint32(HDWalletType) {
+		return Bip44Chain{}, fmt.Errorf("%w: %q: purpose must be %d", ErrInvalidBip44Path, path, HDWalletType)
+	}
+
+	chain := Bip44Chain{
+		CoinType:     indices[1],
+		Account:      indices[2],
+		Change:       indices[3],
+		AddressIndex: indices[4],
+	}
+
+	if err := chain.Validate(); err != nil {
+		return Bip44Chain{}, err
+	}
+
+	return chain, nil
+}
+
+func parseBip44Index(segment string) (uint32, error) {
+	// At most one hardened marker
+	if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H") {
+		segment = segment[:len(segment)-1]
+	}
+	if segment == "" {
+		return 0, errors.New("empty index")
+	}
+
+	// Only plain decimal digits are allowed, no signs or spaces
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid index %q", segment)
+		}
+	}
+
+	index, err := strconv.ParseUint(segment, 10, 32)
+	if err != nil || uint32(index) >= Bip44HardenedOffset {
+		return 0, fmt.Errorf("index %s out of range, must be smaller than %d", segment, Bip44HardenedOffset)
+	}
+
+	return uint32(index), nil
+}
+
+// NewBip44Chain returns the chain of an address, internal selects the change (remainder) branch.
+func NewBip44Chain(coinType CoinType, accountIndex uint32, addressIndex uint32, internal bool) Bip44Chain {
+	change := Bip44ChangeExternal
+	if internal {
+		change = Bip44ChangeInternal
+	}
+
+	return Bip44Chain{
+		CoinType:     uint32(coinType),
+		Account:      accountIndex,
+		Change:       change,
+		AddressIndex: addressIndex,
+	}
+}
+
+// Bip44ChainsFromOptions returns the chains of all addresses described by the generation options.
+func Bip44ChainsFromOptions(options IGenerateAddressesOptions) ([]Bip44Chain, error) {
+	if options.Range.End < options.Range.Start {
+		return nil, fmt.Errorf("invalid address range %d..%d", options.Range.Start, options.Range.End)
+	}
+
+	internal := options.Options != nil && options.Options.Internal
+
+	chains := make([]Bip44Chain, 0, options.Range.End-options.Range.Start)
+	for addressIndex := options.Range.Start; addressIndex < options.Range.End; addressIndex++ {
+		chain := NewBip44Chain(options.CoinType, options.AccountIndex, addressIndex, internal)
+		if err := chain.Validate(); err != nil {
+			return nil, err
+		}
+
+		chains = append(chains, chain)
+	}
+
+	return chains, nil
+}
+
+// Validate checks that every index can be hardened and that change is either external or internal.
+func (c Bip44Chain) Validate() error {
+	for _, index := range []struct {
+		name  string
+		value uint32
+	}{
+		{"coin type", c.CoinType},
+		{"account", c.Account},
+		{"change", c.Change},
+		{"address index", c.AddressIndex},
+	} {
+		if index.value >= Bip44HardenedOffset {
+			return fmt.Errorf("%w: %s %d out of range, must be smaller than %d", ErrInvalidBip44Path, index.name, index.value, Bip44HardenedOffset)
+		}
+	}
+
+	if c.Change != Bip44ChangeExternal && c.Change != Bip44ChangeInternal {
+		return fmt.Errorf("%w: change must be %d or %d, got %d", ErrInvalidBip44Path, Bip44ChangeExternal, Bip44ChangeInternal, c.Change)
+	}
+
+	return nil
+}
+
+// IsInternal returns true if the chain points to the change (remainder) branch.
+func (c Bip44Chain) IsInternal() bool {
+	return c.Change == Bip44ChangeInternal
+}
+
+// String returns the path with all indices hardened, as used by the SDK (SLIP-10).
+func (c Bip44Chain) String() string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d'/%d'", HDWalletType, c.CoinType, c.Account, c.Change, c.AddressIndex)
+}
@@ -0,0 +1,57 @@
+package types
+
+// The different states of ledger inclusion
+type LedgerInclusionState string
+
+const (
+	LedgerInclusionStateNoTransaction LedgerInclusionState = "noTransaction"
+	LedgerInclusionStateIncluded      LedgerInclusionState = "included"
+	LedgerInclusionStateConflicting   LedgerInclusionState = "conflicting"
+)
+
+// Options to build a new block with a tagged data payload
+type BuildBlockOptions struct {
+	// Tag of the tagged data payload
+	Tag HexEncodedString `json:"tag,omitempty" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+
+	// Data of the tagged data payload
+	Data HexEncodedString `json:"data,omitempty" yaml:"data,omitempty" mapstructure:"data,omitempty"`
+
+	// Parent block IDs, selected by the node if empty
+	Parents []HexEncodedString `json:"parents,omitempty" yaml:"parents,omitempty" mapstructure:"parents,omitempty"`
+}
+
+// Response from the metadata endpoint.
+type BlockMetadata struct {
+	// The block id.
+	BlockId HexEncodedString `json:"blockId" yaml:"blockId" mapstructure:"blockId"`
+
+	// The parent block ids.
+	Parents []HexEncodedString `json:"parents" yaml:"parents" mapstructure:"parents"`
+
+	// Is the block solid.
+	IsSolid bool `json:"isSolid" yaml:"isSolid" mapstructure:"isSolid"`
+
+	// Is the block referenced by a milestone.
+	ReferencedByMilestoneIndex uint32 `json:"referencedByMilestoneIndex,omitempty" yaml:"referencedByMilestoneIndex,omitempty" mapstructure:"referencedByMilestoneIndex,omitempty"`
+
+	// Is this block a valid milestone.
+	MilestoneIndex uint32 `json:"milestoneIndex,omitempty" yaml:"milestoneIndex,omitempty" mapstructure:"milestoneIndex,omitempty"`
+
+	// The ledger inclusion state.
+	LedgerInclusionState LedgerInclusionState `json:"ledgerInclusionState,omitempty" yaml:"ledgerInclusionState,omitempty" mapstructure:"ledgerInclusionState,omitempty"`
+
+	// The conflict reason.
+	ConflictReason uint8 `json:"conflictReason,omitempty" yaml:"conflictReason,omitempty" mapstructure:"conflictReason,omitempty"`
+
+	// Should the block be promoted.
+	ShouldPromote *bool `json:"shouldPromote,omitempty" yaml:"shouldPromote,omitempty" mapstructure:"shouldPromote,omitempty"`
+
+	// Should the block be reattached.
+	ShouldReattach *bool `json:"shouldReattach,omitempty" yaml:"shouldReattach,omitempty" mapstructure:"shouldReattach,omitempty"`
+}
+
+// IsReferenced returns true once a milestone referenced the block, its inclusion state is final then
+func (m *BlockMetadata) IsReferenced() bool {
+	return m.ReferencedByMilestoneIndex != 0
+}
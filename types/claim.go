@@ -0,0 +1,93 @@
+package types
+
+// ClaimableOutputsFilter selects which claimable outputs are returned
+type ClaimableOutputsFilter string
+
+const (
+	ClaimableOutputsFilterAll    ClaimableOutputsFilter = "All"
+	ClaimableOutputsFilterNative ClaimableOutputsFilter = "NativeTokens"
+	ClaimableOutputsFilterNft    ClaimableOutputsFilter = "Nfts"
+	ClaimableOutputsFilterAmount ClaimableOutputsFilter = "Amount"
+
+	// ClaimableOutputsFilterStorageDepositReturn selects outputs which have to return a storage deposit to the sender when claimed
+	ClaimableOutputsFilterStorageDepositReturn ClaimableOutputsFilter = "MicroTransactions"
+)
+
+// StorageDepositReturn has to be sent back to the return address when the output is claimed
+type StorageDepositReturn struct {
+	ReturnAddress *Address
+	Amount        string
+}
+
+// Expiration makes the output claimable by the return address after UnixTime
+type Expiration struct {
+	ReturnAddress *Address
+	UnixTime      uint32
+}
+
+// ClaimableOutput is an output sent to the account with unlock conditions that require it to be claimed
+type ClaimableOutput struct {
+	OutputId     OutputId
+	OutputType   OutputType
+	Amount       string
+	NativeTokens []INativeToken
+
+	// NftId is set for NFT outputs
+	NftId HexEncodedString
+
+	Sender *Address
+
+	// StorageDepositReturn, Expiration and Timelock are nil if the output has no such unlock condition
+	StorageDepositReturn *StorageDepositReturn
+	Expiration           *Expiration
+
+	// Timelock is the unix time before which the output can't be claimed
+	Timelock *uint32
+}
+
+// NewClaimableOutput reads the claim conditions of an output
+func NewClaimableOutput(outputData OutputData) (*ClaimableOutput, error) {
+	output, err := ParseOutput(outputData.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	claimable := &ClaimableOutput{
+		OutputId:     outputData.OutputId,
+		OutputType:   output.Type,
+		Amount:       output.Amount,
+		NativeTokens: output.NativeTokens,
+		NftId:        output.NftId,
+	}
+
+	if output.Type == OutputTypeNft && IsNullId(claimable.NftId) {
+		if claimable.NftId, err = ComputeOutputChainId(outputData.OutputId); err != nil {
+			return nil, err
+		}
+	}
+
+	if feature := output.Feature(FeatureTypeSender); feature != nil {
+		claimable.Sender = feature.Address
+	}
+
+	if unlockCondition := output.UnlockCondition(UnlockConditionTypeStorageDepositReturn); unlockCondition != nil {
+		claimable.StorageDepositReturn = &StorageDepositReturn{
+			ReturnAddress: unlockCondition.ReturnAddress,
+			Amount:        unlockCondition.Amount,
+		}
+	}
+
+	if unlockCondition := output.UnlockCondition(UnlockConditionTypeExpiration); unlockCondition != nil {
+		claimable.Expiration = &Expiration{
+			ReturnAddress: unlockCondition.ReturnAddress,
+			UnixTime:      unlockCondition.UnixTime,
+		}
+	}
+
+	if unlockCondition := output.UnlockCondition(UnlockConditionTypeTimelock); unlockCondition != nil {
+		unixTime := unlockCondition.UnixTime
+		claimable.Timelock = &unixTime
+	}
+
+	return claimable, nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import "math/big"
+
+// DefaultOutputConsolidationThreshold is the amount of outputs from which on the SDK consolidates without Force, for
+// software secret managers. Ledger secret managers use LedgerOutputConsolidationThreshold.
+const (
+	DefaultOutputConsolidationThreshold = 100
+	LedgerOutputConsolidationThreshold  = 15
+)
+
+// OutputConsolidationThreshold returns the threshold the SDK applies to wallets with the secret manager, if no OutputThreshold is given
+func OutputConsolidationThreshold(secretManager WalletOptionsSecretManager) uint32 {
+	switch secretManager.(type) {
+	case LedgerNanoSecretManager, *LedgerNanoSecretManager:
+		return LedgerOutputConsolidationThreshold
+	default:
+		return DefaultOutputConsolidationThreshold
+	}
+}
+
+// Parameters for output consolidation
+type ConsolidationParams struct {
+	// Force consolidation even if there are less outputs than the threshold
+	Force bool `json:"force" yaml:"force" mapstructure:"force"`
+
+	// OutputThreshold is the minimum amount of outputs to consolidate, the SDK default is used if 0
+	OutputThreshold uint32 `json:"outputThreshold,omitempty" yaml:"outputThreshold,omitempty" mapstructure:"outputThreshold,omitempty"`
+
+	// Bech32 encoded address that receives the consolidated output, the first address of the account is used if empty
+	TargetAddress string `json:"targetAddress,omitempty" yaml:"targetAddress,omitempty" mapstructure:"targetAddress,omitempty"`
+}
+
+// ConsolidationPreview describes what a consolidation would do, without sending a transaction
+type ConsolidationPreview struct {
+	// OutputIds of the outputs that would be merged, empty if the threshold is not reached
+	OutputIds []OutputId
+
+	// FreedStorageDeposit is an estimate of the storage deposit that becomes available, based on the average
+	// storage deposit of the basic outputs of the account
+	FreedStorageDeposit *big.Int
+}
+
+// IsConsolidatable returns true if the output only has an address unlock condition, as only those are merged
+func IsConsolidatable(outputData OutputData) bool {
+	if outputData.IsSpent {
+		return false
+	}
+
+	output, err := ParseOutput(outputData.Output)
+	if err != nil || output.Type != OutputTypeBasic {
+		return false
+	}
+
+	return len(output.UnlockConditions) == 1 && output.UnlockConditions[0].Type == UnlockConditionTypeAddress
+}
+
+// NewConsolidationPreview selects the consolidatable outputs and estimates the freed storage deposit.
+// basicOutputCount and requiredBasicStorageDeposit are taken from the account balance.
+func NewConsolidationPreview(params ConsolidationParams, outputs []OutputData, lockedOutputs []OutputId, basicOutputCount int, requiredBasicStorageDeposit *big.Int) *ConsolidationPreview {
+	locked := make(map[OutputId]struct{}, len(lockedOutputs))
+	for _, outputId := range lockedOutputs {
+		locked[outputId] = struct{}{}
+	}
+
+	preview := &ConsolidationPreview{
+		OutputIds:           make([]OutputId, 0),
+		FreedStorageDeposit: new(big.Int),
+	}
+
+	for _, output := range outputs {
+		if _, isLocked := locked[output.OutputId]; isLocked || !IsConsolidatable(output) {
+			continue
+		}
+
+		preview.OutputIds = append(preview.OutputIds, output.OutputId)
+	}
+
+	threshold := int(params.OutputThreshold)
+	if threshold == 0 {
+		threshold = DefaultOutputConsolidationThreshold
+	}
+
+	// Merging a single output frees nothing
+	if len(preview.OutputIds) < 2 || (!params.Force && len(preview.OutputIds) < threshold) {
+		preview.OutputIds = preview.OutputIds[:0]
+		return preview
+	}
+
+	if basicOutputCount > 0 && requiredBasicStorageDeposit != nil {
+		preview.FreedStorageDeposit.Mul(requiredBasicStorageDeposit, big.NewInt(int64(len(preview.OutputIds)-1)))
+		preview.FreedStorageDeposit.Quo(preview.FreedStorageDeposit, big.NewInt(int64(basicOutputCount)))
+	}
+
+	return preview
+}
@@ -0,0 +1,35 @@
+package types
+
+import "encoding/json"
+
+type WalletEventType uint8
+
+const (
+	WalletEventTypeConsolidationRequired   WalletEventType = 0
+	WalletEventTypeLedgerAddressGeneration WalletEventType = 1
+	WalletEventTypeNewOutput               WalletEventType = 2
+	WalletEventTypeSpentOutput             WalletEventType = 3
+	WalletEventTypeTransactionInclusion    WalletEventType = 4
+	WalletEventTypeTransactionProgress     WalletEventType = 5
+)
+
+// WalletEvent is emitted by the wallet for one of its accounts
+type WalletEvent struct {
+	AccountIndex uint32 `json:"accountIndex" yaml:"accountIndex" mapstructure:"accountIndex"`
+
+	// Event is the raw event, its type can be read with Type
+	Event json.RawMessage `json:"event" yaml:"event" mapstructure:"event"`
+}
+
+// Type returns the type of the event
+func (e WalletEvent) Type() (WalletEventType, error) {
+	var event struct {
+		Type WalletEventType `json:"type"`
+	}
+
+	if err := json.Unmarshal(e.Event, &event); err != nil {
+		return 0, err
+	}
+
+	return event.Type, nil
+}
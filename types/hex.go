@@ -0,0 +1,22 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NewHexEncodedString encodes bytes with a 0x prefix, as used by the SDK
+func NewHexEncodedString(data []byte) HexEncodedString {
+	return HexEncodedString("0x" + hex.EncodeToString(data))
+}
+
+// Bytes decodes the string, the 0x prefix is optional
+func (h HexEncodedString) Bytes() ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(string(h), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex string %q: %w", h, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,264 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const Irc30Standard = "IRC30"
+
+var ErrInvalidIrc30Metadata = errors.New("invalid IRC30 metadata")
+
+// NewHexEncodedAmount encodes a non negative amount as hex encoded U256
+func NewHexEncodedAmount(amount *big.Int) (HexEncodedAmount, error) {
+	if amount == nil || amount.Sign() < 0 || amount.BitLen() > 256 {
+		return "", fmt.Errorf("amount %v is not a valid U256", amount)
+	}
+
+	return HexEncodedAmount("0x" + amount.Text(16)), nil
+}
+
+// BigInt decodes the amount, an empty amount is zero
+func (a HexEncodedAmount) BigInt() (*big.Int, error) {
+	digits := strings.TrimPrefix(string(a), "0x")
+	if digits == "" {
+		return new(big.Int), nil
+	}
+
+	amount, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex encoded amount %q", a)
+	}
+
+	return amount, nil
+}
+
+// Irc30Metadata is the native token metadata standard of the IOTA ecosystem (TIP-30), stored in the foundry
+type Irc30Metadata struct {
+	Standard string `json:"standard" yaml:"standard" mapstructure:"standard"`
+	Name     string `json:"name" yaml:"name" mapstructure:"name"`
+	Symbol   string `json:"symbol" yaml:"symbol" mapstructure:"symbol"`
+	Decimals uint32 `json:"decimals" yaml:"decimals" mapstructure:"decimals"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+	Url         string `json:"url,omitempty" yaml:"url,omitempty" mapstructure:"url,omitempty"`
+	LogoUrl     string `json:"logoUrl,omitempty" yaml:"logoUrl,omitempty" mapstructure:"logoUrl,omitempty"`
+
+	// Logo as SVG
+	Logo string `json:"logo,omitempty" yaml:"logo,omitempty" mapstructure:"logo,omitempty"`
+}
+
+func NewIrc30Metadata(name string, symbol string, decimals uint32) Irc30Metadata {
+	return Irc30Metadata{
+		Standard: Irc30Standard,
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}
+}
+
+func (m Irc30Metadata) Validate() error {
+	if m.Standard != Irc30Standard {
+		return fmt.Errorf("%w: standard must be %s, got %q", ErrInvalidIrc30Metadata, Irc30Standard, m.Standard)
+	}
+
+	if m.Name == "" || m.Symbol == "" {
+		return fmt.Errorf("%w: name and symbol are required", ErrInvalidIrc30Metadata)
+	}
+
+	return nil
+}
+
+// Hex validates and encodes the metadata to be used as foundry metadata
+func (m Irc30Metadata) Hex() (HexEncodedString, error) {
+	if err := m.Validate(); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return NewHexEncodedString(data), nil
+}
+
+// ParseIrc30Metadata decodes hex encoded foundry metadata, returns an error if it doesn't follow IRC30
+func ParseIrc30Metadata(data HexEncodedString) (*Irc30Metadata, error) {
+	decoded, err := data.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := new(Irc30Metadata)
+	if err := json.Unmarshal(decoded, metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIrc30Metadata, err)
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// Native token options for minting, as expected by the SDK
+type MintNativeTokenParams struct {
+	// AliasId of the alias output which controls the foundry, the first alias of the account is used if empty
+	AliasId HexEncodedString `json:"aliasId,omitempty" yaml:"aliasId,omitempty" mapstructure:"aliasId,omitempty"`
+
+	// CirculatingSupply corresponds to the JSON schema field "circulatingSupply".
+	CirculatingSupply HexEncodedAmount `json:"circulatingSupply" yaml:"circulatingSupply" mapstructure:"circulatingSupply"`
+
+	// FoundryMetadata corresponds to the JSON schema field "foundryMetadata".
+	FoundryMetadata HexEncodedString `json:"foundryMetadata,omitempty" yaml:"foundryMetadata,omitempty" mapstructure:"foundryMetadata,omitempty"`
+
+	// MaximumSupply corresponds to the JSON schema field "maximumSupply".
+	MaximumSupply HexEncodedAmount `json:"maximumSupply" yaml:"maximumSupply" mapstructure:"maximumSupply"`
+}
+
+// CreateNativeTokenParams describes a new native token and its foundry
+type CreateNativeTokenParams struct {
+	// AliasId of the alias output which controls the foundry.
+	// If empty, the first alias of the account is used, or a new alias is created if the account has none.
+	AliasId HexEncodedString
+
+	// CirculatingSupply is minted right away
+	CirculatingSupply *big.Int
+	MaximumSupply     *big.Int
+
+	// Metadata is stored in the foundry, it takes precedence over FoundryMetadata
+	Metadata *Irc30Metadata
+
+	// FoundryMetadata is stored in the foundry as is if Metadata is not set
+	FoundryMetadata HexEncodedString
+}
+
+// MintNativeTokenParams validates and encodes the params for the SDK
+func (p CreateNativeTokenParams) MintNativeTokenParams() (MintNativeTokenParams, error) {
+	if p.CirculatingSupply == nil || p.MaximumSupply == nil {
+		return MintNativeTokenParams{}, errors.New("circulating and maximum supply are required")
+	}
+
+	if p.MaximumSupply.Sign() <= 0 || p.CirculatingSupply.Cmp(p.MaximumSupply) > 0 {
+		return MintNativeTokenParams{}, fmt.Errorf("circulating supply %v must not exceed maximum supply %v", p.CirculatingSupply, p.MaximumSupply)
+	}
+
+	circulatingSupply, err := NewHexEncodedAmount(p.CirculatingSupply)
+	if err != nil {
+		return MintNativeTokenParams{}, err
+	}
+
+	maximumSupply, err := NewHexEncodedAmount(p.MaximumSupply)
+	if err != nil {
+		return MintNativeTokenParams{}, err
+	}
+
+	foundryMetadata := p.FoundryMetadata
+	if p.Metadata != nil {
+		if foundryMetadata, err = p.Metadata.Hex(); err != nil {
+			return MintNativeTokenParams{}, err
+		}
+	}
+
+	return MintNativeTokenParams{
+		AliasId:           p.AliasId,
+		CirculatingSupply: circulatingSupply,
+		FoundryMetadata:   foundryMetadata,
+		MaximumSupply:     maximumSupply,
+	}, nil
+}
+
+// The result of preparing a minting operation
+type PreparedMintTokenTransactionData struct {
+	// The token id of the minted token
+	TokenId HexEncodedString `json:"tokenId" yaml:"tokenId" mapstructure:"tokenId"`
+
+	// The prepared transaction which will mint the token
+	Transaction json.RawMessage `json:"transaction" yaml:"transaction" mapstructure:"transaction"`
+}
+
+// CreateNativeTokenTransaction is the transaction which created a foundry and minted its native token
+type CreateNativeTokenTransaction struct {
+	// TokenId of the new native token, which is also the ID of its foundry
+	TokenId     HexEncodedString
+	Transaction Transaction
+}
+
+// The balance of the base coin
+type BaseCoinBalance struct {
+	// The amount of the outputs that aren't used in a transaction
+	Available string `json:"available" yaml:"available" mapstructure:"available"`
+
+	// The total amount of the outputs
+	Total string `json:"total" yaml:"total" mapstructure:"total"`
+
+	// Voting power
+	VotingPower string `json:"votingPower" yaml:"votingPower" mapstructure:"votingPower"`
+}
+
+// The balance of a native token
+type NativeTokenBalance struct {
+	// Available corresponds to the JSON schema field "available".
+	Available HexEncodedAmount `json:"available" yaml:"available" mapstructure:"available"`
+
+	// Hex encoded foundry metadata, only set if the foundry was synced
+	Metadata HexEncodedString `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+
+	// TokenId corresponds to the JSON schema field "tokenId".
+	TokenId HexEncodedString `json:"tokenId" yaml:"tokenId" mapstructure:"tokenId"`
+
+	// Total corresponds to the JSON schema field "total".
+	Total HexEncodedAmount `json:"total" yaml:"total" mapstructure:"total"`
+}
+
+// Irc30 decodes the foundry metadata, it returns nil if the metadata is missing or doesn't follow IRC30
+func (b NativeTokenBalance) Irc30() *Irc30Metadata {
+	if b.Metadata == "" {
+		return nil
+	}
+
+	metadata, err := ParseIrc30Metadata(b.Metadata)
+	if err != nil {
+		return nil
+	}
+
+	return metadata
+}
+
+// The required storage deposit per output type
+type RequiredStorageDeposit struct {
+	Alias   string `json:"alias" yaml:"alias" mapstructure:"alias"`
+	Basic   string `json:"basic" yaml:"basic" mapstructure:"basic"`
+	Foundry string `json:"foundry" yaml:"foundry" mapstructure:"foundry"`
+	Nft     string `json:"nft" yaml:"nft" mapstructure:"nft"`
+}
+
+// The balance of an account
+type Balance struct {
+	// Alias outputs
+	Aliases []HexEncodedString `json:"aliases" yaml:"aliases" mapstructure:"aliases"`
+
+	// The balance of the base coin
+	BaseCoin BaseCoinBalance `json:"baseCoin" yaml:"baseCoin" mapstructure:"baseCoin"`
+
+	// Foundry outputs
+	Foundries []HexEncodedString `json:"foundries" yaml:"foundries" mapstructure:"foundries"`
+
+	// The balance of the native tokens
+	NativeTokens []NativeTokenBalance `json:"nativeTokens" yaml:"nativeTokens" mapstructure:"nativeTokens"`
+
+	// Nft outputs
+	Nfts []HexEncodedString `json:"nfts" yaml:"nfts" mapstructure:"nfts"`
+
+	// Outputs with multiple unlock conditions and if they can currently be spent or
+	// not. If there is a TimelockUnlockCondition or ExpirationUnlockCondition this
+	// can change at any time
+	PotentiallyLockedOutputs map[OutputId]bool `json:"potentiallyLockedOutputs" yaml:"potentiallyLockedOutputs" mapstructure:"potentiallyLockedOutputs"`
+
+	// The required storage deposit for the outputs
+	RequiredStorageDeposit RequiredStorageDeposit `json:"requiredStorageDeposit" yaml:"requiredStorageDeposit" mapstructure:"requiredStorageDeposit"`
+}
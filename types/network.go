@@ -0,0 +1,302 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// Network describes the metadata of a network that is associated with a coin type.
+type Network struct {
+	// Name of the network, unique within the registry
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// CoinType used in the BIP44 path of the network
+	CoinType CoinType `json:"coinType" yaml:"coinType" mapstructure:"coinType"`
+
+	// Bech32Hrp is the human readable part of bech32 addresses on the main network
+	Bech32Hrp string `json:"bech32Hrp,omitempty" yaml:"bech32Hrp,omitempty" mapstructure:"bech32Hrp,omitempty"`
+
+	// TestnetBech32Hrp is the human readable part of bech32 addresses on the test network
+	TestnetBech32Hrp string `json:"testnetBech32Hrp,omitempty" yaml:"testnetBech32Hrp,omitempty" mapstructure:"testnetBech32Hrp,omitempty"`
+
+	// Decimals of the base token
+	Decimals uint32 `json:"decimals" yaml:"decimals" mapstructure:"decimals"`
+
+	// TokenSymbol of the base token
+	TokenSymbol string `json:"tokenSymbol" yaml:"tokenSymbol" mapstructure:"tokenSymbol"`
+}
+
+var (
+	NetworkIOTA = Network{
+		Name:             "iota",
+		CoinType:         CoinTypeIOTA,
+		Bech32Hrp:        "iota",
+		TestnetBech32Hrp: "atoi",
+		Decimals:         6,
+		TokenSymbol:      "IOTA",
+	}
+
+	NetworkShimmer = Network{
+		Name:             "shimmer",
+		CoinType:         CoinTypeSMR,
+		Bech32Hrp:        "smr",
+		TestnetBech32Hrp: "rms",
+		Decimals:         6,
+		TokenSymbol:      "SMR",
+	}
+
+	// NetworkEther has no bech32 HRPs, as EVM addresses are hex encoded
+	NetworkEther = Network{
+		Name:        "ethereum",
+		CoinType:    CoinTypeEther,
+		Decimals:    18,
+		TokenSymbol: "ETH",
+	}
+)
+
+var (
+	ErrUnknownNetwork = errors.New("unknown network")
+	ErrInvalidNetwork = errors.New("invalid network")
+)
+
+var builtInNetworks = []Network{NetworkIOTA, NetworkShimmer, NetworkEther}
+
+var networkRegistry = struct {
+	sync.RWMutex
+	networks []Network
+}{
+	networks: append([]Network(nil), builtInNetworks...),
+}
+
+// RegisterNetwork adds a network to the registry, or replaces the custom network with the same name.
+// Built-in networks can't be replaced and their coin types can't be reused, the HRPs of all networks have to be unique.
+// Custom networks may share a coin type, lookups by coin type return the network that was registered first.
+func RegisterNetwork(network Network) error {
+	if err := network.Validate(); err != nil {
+		return err
+	}
+
+	for _, builtIn := range builtInNetworks {
+		if builtIn.Name == network.Name {
+			return fmt.Errorf("%w: network %q is built-in", ErrInvalidNetwork, network.Name)
+		}
+		if builtIn.CoinType == network.CoinType {
+			return fmt.Errorf("%w: coin type %d is used by the built-in network %q", ErrInvalidNetwork, network.CoinType, builtIn.Name)
+		}
+	}
+
+	networkRegistry.Lock()
+	defer networkRegistry.Unlock()
+
+	replaceIndex := -1
+	for i, registered := range networkRegistry.networks {
+		if registered.Name == network.Name {
+			replaceIndex = i
+			continue
+		}
+
+		for _, hrp := range network.bech32Hrps() {
+			if registered.hasBech32Hrp(hrp) {
+				return fmt.Errorf("%w: HRP %q is already used by network %q", ErrInvalidNetwork, hrp, registered.Name)
+			}
+		}
+	}
+
+	if replaceIndex >= 0 {
+		networkRegistry.networks[replaceIndex] = network
+	} else {
+		networkRegistry.networks = append(networkRegistry.networks, network)
+	}
+
+	return nil
+}
+
+// UnregisterNetwork removes a network from the registry by its name. Built-in networks can't be removed.
+func UnregisterNetwork(name string) error {
+	for _, builtIn := range builtInNetworks {
+		if builtIn.Name == name {
+			return fmt.Errorf("%w: network %q is built-in", ErrInvalidNetwork, name)
+		}
+	}
+
+	networkRegistry.Lock()
+	defer networkRegistry.Unlock()
+
+	for i, registered := range networkRegistry.networks {
+		if registered.Name == name {
+			networkRegistry.networks = append(networkRegistry.networks[:i], networkRegistry.networks[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownNetwork, name)
+}
+
+// Networks returns all registered networks.
+func Networks() []Network {
+	networkRegistry.RLock()
+	defer networkRegistry.RUnlock()
+
+	networks := make([]Network, len(networkRegistry.networks))
+	copy(networks, networkRegistry.networks)
+
+	return networks
+}
+
+// NetworkByName returns the registered network with the given name.
+func NetworkByName(name string) (Network, error) {
+	networkRegistry.RLock()
+	defer networkRegistry.RUnlock()
+
+	for _, network := range networkRegistry.networks {
+		if network.Name == name {
+			return network, nil
+		}
+	}
+
+	return Network{}, fmt.Errorf("%w: name %q", ErrUnknownNetwork, name)
+}
+
+// NetworkByCoinType returns the first registered network using the coin type.
+func NetworkByCoinType(coinType CoinType) (Network, error) {
+	networkRegistry.RLock()
+	defer networkRegistry.RUnlock()
+
+	for _, network := range networkRegistry.networks {
+		if network.CoinType == coinType {
+			return network, nil
+		}
+	}
+
+	return Network{}, fmt.Errorf("%w: coin type %d", ErrUnknownNetwork, coinType)
+}
+
+// NetworkByBech32Hrp returns the network using the HRP either for its main or test network.
+func NetworkByBech32Hrp(hrp string) (Network, error) {
+	networkRegistry.RLock()
+	defer networkRegistry.RUnlock()
+
+	for _, network := range networkRegistry.networks {
+		if network.hasBech32Hrp(hrp) {
+			return network, nil
+		}
+	}
+
+	return Network{}, fmt.Errorf("%w: HRP %q", ErrUnknownNetwork, hrp)
+}
+
+// ResolveBech32Hrp returns bech32Hrp if it is set, otherwise the default (mainnet) HRP of the coin type.
+func ResolveBech32Hrp(coinType CoinType, bech32Hrp string) (string, error) {
+	if bech32Hrp != "" {
+		return bech32Hrp, nil
+	}
+
+	network, err := NetworkByCoinType(coinType)
+	if err != nil {
+		return "", err
+	}
+
+	if network.Bech32Hrp == "" {
+		return "", fmt.Errorf("%w: network %q has no bech32 HRP", ErrUnknownNetwork, network.Name)
+	}
+
+	return network.Bech32Hrp, nil
+}
+
+// Network returns the first registered network using the coin type.
+func (c CoinType) Network() (Network, error) {
+	return NetworkByCoinType(c)
+}
+
+// DefaultBech32Hrp returns the mainnet HRP of the coin type, or an empty string if it's unknown.
+func (c CoinType) DefaultBech32Hrp() string {
+	hrp, err := ResolveBech32Hrp(c, "")
+	if err != nil {
+		return ""
+	}
+
+	return hrp
+}
+
+func (n Network) Validate() error {
+	if n.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidNetwork)
+	}
+
+	if n.TestnetBech32Hrp != "" && n.TestnetBech32Hrp == n.Bech32Hrp {
+		return fmt.Errorf("%w: mainnet and testnet HRP are both %q", ErrInvalidNetwork, n.Bech32Hrp)
+	}
+
+	for _, hrp := range n.bech32Hrps() {
+		if hrp != strings.ToLower(hrp) {
+			return fmt.Errorf("%w: HRP %q must be lowercase", ErrInvalidNetwork, hrp)
+		}
+
+		for _, c := range hrp {
+			if c < 33 || c > 126 {
+				return fmt.Errorf("%w: HRP %q contains invalid characters", ErrInvalidNetwork, hrp)
+			}
+		}
+	}
+
+	if n.TokenSymbol == "" {
+		return fmt.Errorf("%w: token symbol is empty", ErrInvalidNetwork)
+	}
+
+	return nil
+}
+
+// DefaultBip44Chain returns the chain of the first public address of the first account.
+func (n Network) DefaultBip44Chain() Bip44Chain {
+	return NewBip44Chain(n.CoinType, 0, 0, false)
+}
+
+// IsTestnetBech32Hrp returns true if the HRP is the testnet HRP of the network.
+func (n Network) IsTestnetBech32Hrp(hrp string) bool {
+	return n.TestnetBech32Hrp != "" && n.TestnetBech32Hrp == hrp
+}
+
+// FormatAmount formats an amount of the smallest unit as a decimal value with the token symbol, e.g. "1.5 SMR".
+func (n Network) FormatAmount(amount *big.Int) string {
+	if amount == nil {
+		amount = new(big.Int)
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	if n.Decimals > 0 {
+		if missing := int(n.Decimals) + 1 - len(digits); missing > 0 {
+			digits = strings.Repeat("0", missing) + digits
+		}
+
+		integer, fraction := digits[:len(digits)-int(n.Decimals)], strings.TrimRight(digits[len(digits)-int(n.Decimals):], "0")
+		digits = integer
+		if fraction != "" {
+			digits += "." + fraction
+		}
+	}
+
+	if amount.Sign() < 0 {
+		digits = "-" + digits
+	}
+
+	return digits + " " + n.TokenSymbol
+}
+
+func (n Network) bech32Hrps() []string {
+	hrps := make([]string, 0, 2)
+	if n.Bech32Hrp != "" {
+		hrps = append(hrps, n.Bech32Hrp)
+	}
+	if n.TestnetBech32Hrp != "" {
+		hrps = append(hrps, n.TestnetBech32Hrp)
+	}
+
+	return hrps
+}
+
+func (n Network) hasBech32Hrp(hrp string) bool {
+	return hrp != "" && (n.Bech32Hrp == hrp || n.TestnetBech32Hrp == hrp)
+}
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	Irc27Standard = "IRC27"
+	Irc27Version  = "v1.0"
+)
+
+var ErrInvalidIrc27Metadata = errors.New("invalid IRC27 metadata")
+
+// Irc27Attribute is a trait of an NFT
+type Irc27Attribute struct {
+	TraitType string `json:"trait_type" yaml:"trait_type" mapstructure:"trait_type"`
+	Value     any    `json:"value" yaml:"value" mapstructure:"value"`
+}
+
+// Irc27Metadata is the immutable NFT metadata standard of the IOTA ecosystem (TIP-27)
+type Irc27Metadata struct {
+	Standard string `json:"standard" yaml:"standard" mapstructure:"standard"`
+	Version  string `json:"version" yaml:"version" mapstructure:"version"`
+
+	// MIME type of the asset
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+
+	// URI of the asset
+	Uri  string `json:"uri" yaml:"uri" mapstructure:"uri"`
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	CollectionName string `json:"collectionName,omitempty" yaml:"collectionName,omitempty" mapstructure:"collectionName,omitempty"`
+
+	// Royalties by bech32 address, the shares must not exceed 1 in sum
+	Royalties   map[string]float64 `json:"royalties,omitempty" yaml:"royalties,omitempty" mapstructure:"royalties,omitempty"`
+	IssuerName  string             `json:"issuerName,omitempty" yaml:"issuerName,omitempty" mapstructure:"issuerName,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+	Attributes  []Irc27Attribute   `json:"attributes,omitempty" yaml:"attributes,omitempty" mapstructure:"attributes,omitempty"`
+}
+
+func NewIrc27Metadata(mimeType string, uri string, name string) Irc27Metadata {
+	return Irc27Metadata{
+		Standard: Irc27Standard,
+		Version:  Irc27Version,
+		Type:     mimeType,
+		Uri:      uri,
+		Name:     name,
+	}
+}
+
+func (m Irc27Metadata) Validate() error {
+	if m.Standard != Irc27Standard {
+		return fmt.Errorf("%w: standard must be %s, got %q", ErrInvalidIrc27Metadata, Irc27Standard, m.Standard)
+	}
+
+	if m.Version == "" || m.Type == "" || m.Uri == "" || m.Name == "" {
+		return fmt.Errorf("%w: version, type, uri and name are required", ErrInvalidIrc27Metadata)
+	}
+
+	var royalties float64
+	for address, share := range m.Royalties {
+		if share < 0 {
+			return fmt.Errorf("%w: negative royalty for %s", ErrInvalidIrc27Metadata, address)
+		}
+		royalties += share
+	}
+
+	if royalties > 1 {
+		return fmt.Errorf("%w: royalties sum up to %v, must not exceed 1", ErrInvalidIrc27Metadata, royalties)
+	}
+
+	return nil
+}
+
+// Hex validates and encodes the metadata to be used as immutable metadata of an NFT
+func (m Irc27Metadata) Hex() (HexEncodedString, error) {
+	if err := m.Validate(); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return NewHexEncodedString(data), nil
+}
+
+// ParseIrc27Metadata decodes hex encoded metadata, returns an error if it doesn't follow IRC27
+func ParseIrc27Metadata(data HexEncodedString) (*Irc27Metadata, error) {
+	decoded, err := data.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := new(Irc27Metadata)
+	if err := json.Unmarshal(decoded, metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIrc27Metadata, err)
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+type MintNftParams struct {
+	// Bech32 encoded address to which the Nft will be minted. Default will use the
+	// first address of the account
+	Address string `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Bech32 encoded sender address
+	Sender string `json:"sender,omitempty" yaml:"sender,omitempty" mapstructure:"sender,omitempty"`
+
+	// Metadata of the NFT, can be changed by the owner
+	Metadata HexEncodedString `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+
+	// Tag of the NFT
+	Tag HexEncodedString `json:"tag,omitempty" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+
+	// Bech32 encoded issuer address, must be an address of the account
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty" mapstructure:"issuer,omitempty"`
+
+	// Immutable metadata of the NFT, usually IRC27 (see Irc27Metadata.Hex)
+	ImmutableMetadata HexEncodedString `json:"immutableMetadata,omitempty" yaml:"immutableMetadata,omitempty" mapstructure:"immutableMetadata,omitempty"`
+}
+
+type SendNftParams struct {
+	// Bech32 encoded address of the receiver
+	Address string `json:"address" yaml:"address" mapstructure:"address"`
+
+	// NftId corresponds to the JSON schema field "nftId".
+	NftId HexEncodedString `json:"nftId" yaml:"nftId" mapstructure:"nftId"`
+}
+
+// Nft is an NFT owned by an account
+type Nft struct {
+	NftId    HexEncodedString `json:"nftId" yaml:"nftId" mapstructure:"nftId"`
+	OutputId OutputId         `json:"outputId" yaml:"outputId" mapstructure:"outputId"`
+	Amount   string           `json:"amount" yaml:"amount" mapstructure:"amount"`
+
+	Issuer *Address `json:"issuer,omitempty" yaml:"issuer,omitempty" mapstructure:"issuer,omitempty"`
+	Sender *Address `json:"sender,omitempty" yaml:"sender,omitempty" mapstructure:"sender,omitempty"`
+
+	Metadata          HexEncodedString `json:"metadata,omitempty" yaml:"metadata,omitempty" mapstructure:"metadata,omitempty"`
+	ImmutableMetadata HexEncodedString `json:"immutableMetadata,omitempty" yaml:"immutableMetadata,omitempty" mapstructure:"immutableMetadata,omitempty"`
+	Tag               HexEncodedString `json:"tag,omitempty" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+
+	// Irc27 is the decoded immutable metadata, nil if it doesn't follow IRC27
+	Irc27 *Irc27Metadata `json:"irc27,omitempty" yaml:"irc27,omitempty" mapstructure:"irc27,omitempty"`
+}
+
+// NewNft reads the NFT of an unspent NFT output
+func NewNft(outputData OutputData) (*Nft, error) {
+	output, err := ParseOutput(outputData.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Type != OutputTypeNft {
+		return nil, fmt.Errorf("output %s is not an NFT output", outputData.OutputId)
+	}
+
+	nft := &Nft{
+		NftId:    output.NftId,
+		OutputId: outputData.OutputId,
+		Amount:   output.Amount,
+	}
+
+	// The NFT ID is only set in outputs after the minting output was spent
+	if IsNullId(nft.NftId) {
+		if nft.NftId, err = ComputeOutputChainId(outputData.OutputId); err != nil {
+			return nil, err
+		}
+	}
+
+	if feature := output.ImmutableFeature(FeatureTypeIssuer); feature != nil {
+		nft.Issuer = feature.Address
+	}
+	if feature := output.ImmutableFeature(FeatureTypeMetadata); feature != nil {
+		nft.ImmutableMetadata = feature.Data
+		nft.Irc27, _ = ParseIrc27Metadata(feature.Data)
+	}
+	if feature := output.Feature(FeatureTypeSender); feature != nil {
+		nft.Sender = feature.Address
+	}
+	if feature := output.Feature(FeatureTypeMetadata); feature != nil {
+		nft.Metadata = feature.Data
+	}
+	if feature := output.Feature(FeatureTypeTag); feature != nil {
+		nft.Tag = feature.Tag
+	}
+
+	return nft, nil
+}
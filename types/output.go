@@ -0,0 +1,239 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+type OutputType uint8
+
+const (
+	OutputTypeBasic   OutputType = 3
+	OutputTypeAlias   OutputType = 4
+	OutputTypeFoundry OutputType = 5
+	OutputTypeNft     OutputType = 6
+)
+
+type AddressType uint8
+
+const (
+	AddressTypeEd25519 AddressType = 0
+	AddressTypeAlias   AddressType = 8
+	AddressTypeNft     AddressType = 16
+)
+
+type FeatureType uint8
+
+const (
+	FeatureTypeSender   FeatureType = 0
+	FeatureTypeIssuer   FeatureType = 1
+	FeatureTypeMetadata FeatureType = 2
+	FeatureTypeTag      FeatureType = 3
+)
+
+type UnlockConditionType uint8
+
+const (
+	UnlockConditionTypeAddress                UnlockConditionType = 0
+	UnlockConditionTypeStorageDepositReturn   UnlockConditionType = 1
+	UnlockConditionTypeTimelock               UnlockConditionType = 2
+	UnlockConditionTypeExpiration             UnlockConditionType = 3
+	UnlockConditionTypeStateControllerAddress UnlockConditionType = 4
+	UnlockConditionTypeGovernorAddress        UnlockConditionType = 5
+	UnlockConditionTypeImmutableAliasAddress  UnlockConditionType = 6
+)
+
+// Amount of native tokens, hex encoded U256
+type HexEncodedAmount string
+
+// An address as serialized by the SDK, only the field matching the type is set
+type Address struct {
+	Type AddressType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// PubKeyHash of an Ed25519 address
+	PubKeyHash HexEncodedString `json:"pubKeyHash,omitempty" yaml:"pubKeyHash,omitempty" mapstructure:"pubKeyHash,omitempty"`
+
+	// AliasId of an alias address
+	AliasId HexEncodedString `json:"aliasId,omitempty" yaml:"aliasId,omitempty" mapstructure:"aliasId,omitempty"`
+
+	// NftId of an NFT address
+	NftId HexEncodedString `json:"nftId,omitempty" yaml:"nftId,omitempty" mapstructure:"nftId,omitempty"`
+}
+
+type INativeToken struct {
+	// Amount of native tokens of the given Token ID.
+	Amount HexEncodedAmount `json:"amount" yaml:"amount" mapstructure:"amount"`
+
+	// Identifier of the native token.
+	Id HexEncodedString `json:"id" yaml:"id" mapstructure:"id"`
+}
+
+// A feature of an output, only the fields matching the type are set
+type Feature struct {
+	Type FeatureType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// Address of a sender or issuer feature
+	Address *Address `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Data of a metadata feature
+	Data HexEncodedString `json:"data,omitempty" yaml:"data,omitempty" mapstructure:"data,omitempty"`
+
+	// Tag of a tag feature
+	Tag HexEncodedString `json:"tag,omitempty" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+}
+
+// An unlock condition of an output, only the fields matching the type are set
+type UnlockCondition struct {
+	Type UnlockConditionType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// Address of an address, expiration, state controller, governor or immutable alias unlock condition
+	Address *Address `json:"address,omitempty" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// ReturnAddress of a storage deposit return or expiration unlock condition
+	ReturnAddress *Address `json:"returnAddress,omitempty" yaml:"returnAddress,omitempty" mapstructure:"returnAddress,omitempty"`
+
+	// Amount of a storage deposit return unlock condition
+	Amount string `json:"amount,omitempty" yaml:"amount,omitempty" mapstructure:"amount,omitempty"`
+
+	// UnixTime of a timelock or expiration unlock condition
+	UnixTime uint32 `json:"unixTime,omitempty" yaml:"unixTime,omitempty" mapstructure:"unixTime,omitempty"`
+}
+
+// Output is the union of all output types, only the fields matching the type are set
+type Output struct {
+	Type OutputType `json:"type" yaml:"type" mapstructure:"type"`
+
+	Amount string `json:"amount" yaml:"amount" mapstructure:"amount"`
+
+	NativeTokens []INativeToken `json:"nativeTokens,omitempty" yaml:"nativeTokens,omitempty" mapstructure:"nativeTokens,omitempty"`
+
+	// AliasId of an alias output
+	AliasId HexEncodedString `json:"aliasId,omitempty" yaml:"aliasId,omitempty" mapstructure:"aliasId,omitempty"`
+
+	// StateIndex of an alias output
+	StateIndex uint32 `json:"stateIndex,omitempty" yaml:"stateIndex,omitempty" mapstructure:"stateIndex,omitempty"`
+
+	// StateMetadata of an alias output
+	StateMetadata HexEncodedString `json:"stateMetadata,omitempty" yaml:"stateMetadata,omitempty" mapstructure:"stateMetadata,omitempty"`
+
+	// FoundryCounter of an alias output
+	FoundryCounter uint32 `json:"foundryCounter,omitempty" yaml:"foundryCounter,omitempty" mapstructure:"foundryCounter,omitempty"`
+
+	// SerialNumber of a foundry output
+	SerialNumber uint32 `json:"serialNumber,omitempty" yaml:"serialNumber,omitempty" mapstructure:"serialNumber,omitempty"`
+
+	// TokenScheme of a foundry output
+	TokenScheme *SimpleTokenScheme `json:"tokenScheme,omitempty" yaml:"tokenScheme,omitempty" mapstructure:"tokenScheme,omitempty"`
+
+	// NftId of an NFT output
+	NftId HexEncodedString `json:"nftId,omitempty" yaml:"nftId,omitempty" mapstructure:"nftId,omitempty"`
+
+	UnlockConditions []UnlockCondition `json:"unlockConditions" yaml:"unlockConditions" mapstructure:"unlockConditions"`
+
+	Features []Feature `json:"features,omitempty" yaml:"features,omitempty" mapstructure:"features,omitempty"`
+
+	ImmutableFeatures []Feature `json:"immutableFeatures,omitempty" yaml:"immutableFeatures,omitempty" mapstructure:"immutableFeatures,omitempty"`
+}
+
+// The token scheme of a foundry
+type SimpleTokenScheme struct {
+	Type uint8 `json:"type" yaml:"type" mapstructure:"type"`
+
+	MintedTokens HexEncodedAmount `json:"mintedTokens" yaml:"mintedTokens" mapstructure:"mintedTokens"`
+
+	MeltedTokens HexEncodedAmount `json:"meltedTokens" yaml:"meltedTokens" mapstructure:"meltedTokens"`
+
+	MaximumSupply HexEncodedAmount `json:"maximumSupply" yaml:"maximumSupply" mapstructure:"maximumSupply"`
+}
+
+// ParseOutput converts the untyped output of an OutputData into an Output
+func ParseOutput(output OutputDataOutput) (*Output, error) {
+	serialized, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := new(Output)
+	if err := json.Unmarshal(serialized, parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// Feature returns the first feature of the given type
+func (o *Output) Feature(featureType FeatureType) *Feature {
+	return findFeature(o.Features, featureType)
+}
+
+// ImmutableFeature returns the first immutable feature of the given type
+func (o *Output) ImmutableFeature(featureType FeatureType) *Feature {
+	return findFeature(o.ImmutableFeatures, featureType)
+}
+
+// UnlockCondition returns the first unlock condition of the given type
+func (o *Output) UnlockCondition(unlockConditionType UnlockConditionType) *UnlockCondition {
+	for index := range o.UnlockConditions {
+		if o.UnlockConditions[index].Type == unlockConditionType {
+			return &o.UnlockConditions[index]
+		}
+	}
+
+	return nil
+}
+
+func findFeature(features []Feature, featureType FeatureType) *Feature {
+	for index := range features {
+		if features[index].Type == featureType {
+			return &features[index]
+		}
+	}
+
+	return nil
+}
+
+// IsNullId returns true if the id only consists of zeros, as for outputs which were just created
+func IsNullId(id HexEncodedString) bool {
+	return strings.Trim(strings.TrimPrefix(string(id), "0x"), "0") == ""
+}
+
+// ComputeOutputChainId computes the ID of an alias or NFT from the ID of the output that created it
+func ComputeOutputChainId(outputId OutputId) (HexEncodedString, error) {
+	outputIdBytes, err := hex.DecodeString(strings.TrimPrefix(string(outputId), "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid output id %q: %w", outputId, err)
+	}
+
+	if len(outputIdBytes) != 34 {
+		return "", fmt.Errorf("invalid output id %q: expected 34 bytes, got %d", outputId, len(outputIdBytes))
+	}
+
+	hash := blake2b.Sum256(outputIdBytes)
+
+	return HexEncodedString("0x" + hex.EncodeToString(hash[:])), nil
+}
+
+// Options to filter outputs
+type FilterOptions struct {
+	// Filter all outputs where the booked milestone index is below the specified timestamp
+	LowerBoundBookedTimestamp uint32 `json:"lowerBoundBookedTimestamp,omitempty" yaml:"lowerBoundBookedTimestamp,omitempty" mapstructure:"lowerBoundBookedTimestamp,omitempty"`
+
+	// Filter all outputs where the booked milestone index is above the specified timestamp
+	UpperBoundBookedTimestamp uint32 `json:"upperBoundBookedTimestamp,omitempty" yaml:"upperBoundBookedTimestamp,omitempty" mapstructure:"upperBoundBookedTimestamp,omitempty"`
+
+	// Filter all outputs for the provided types
+	OutputTypes []OutputType `json:"outputTypes,omitempty" yaml:"outputTypes,omitempty" mapstructure:"outputTypes,omitempty"`
+
+	// Return all alias outputs matching these IDs
+	AliasIds []HexEncodedString `json:"aliasIds,omitempty" yaml:"aliasIds,omitempty" mapstructure:"aliasIds,omitempty"`
+
+	// Return all foundry outputs matching these IDs
+	FoundryIds []HexEncodedString `json:"foundryIds,omitempty" yaml:"foundryIds,omitempty" mapstructure:"foundryIds,omitempty"`
+
+	// Return all NFT outputs matching these IDs
+	NftIds []HexEncodedString `json:"nftIds,omitempty" yaml:"nftIds,omitempty" mapstructure:"nftIds,omitempty"`
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Possible InclusionStates of transactions sent with the wallet
+type InclusionState string
+
+const (
+	InclusionStatePending       InclusionState = "Pending"
+	InclusionStateConfirmed     InclusionState = "Confirmed"
+	InclusionStateConflicting   InclusionState = "Conflicting"
+	InclusionStateUnknownPruned InclusionState = "UnknownPruned"
+)
+
+// A reference to an output by the transaction which created it
+type UTXOInput struct {
+	Type uint8 `json:"type" yaml:"type" mapstructure:"type"`
+
+	// TransactionId corresponds to the JSON schema field "transactionId".
+	TransactionId HexEncodedString `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+
+	// TransactionOutputIndex corresponds to the JSON schema field "transactionOutputIndex".
+	TransactionOutputIndex uint16 `json:"transactionOutputIndex" yaml:"transactionOutputIndex" mapstructure:"transactionOutputIndex"`
+}
+
+// The essence of a transaction, which is signed by the unlocks
+type TransactionEssence struct {
+	Type uint8 `json:"type" yaml:"type" mapstructure:"type"`
+
+	// NetworkId corresponds to the JSON schema field "networkId".
+	NetworkId string `json:"networkId" yaml:"networkId" mapstructure:"networkId"`
+
+	// Inputs corresponds to the JSON schema field "inputs".
+	Inputs []UTXOInput `json:"inputs" yaml:"inputs" mapstructure:"inputs"`
+
+	// InputsCommitment corresponds to the JSON schema field "inputsCommitment".
+	InputsCommitment HexEncodedString `json:"inputsCommitment" yaml:"inputsCommitment" mapstructure:"inputsCommitment"`
+
+	// Outputs corresponds to the JSON schema field "outputs".
+	Outputs []Output `json:"outputs" yaml:"outputs" mapstructure:"outputs"`
+
+	// Optional tagged data payload
+	Payload *TaggedDataPayload `json:"payload,omitempty" yaml:"payload,omitempty" mapstructure:"payload,omitempty"`
+}
+
+// The transaction payload
+type TransactionPayload struct {
+	Type uint8 `json:"type" yaml:"type" mapstructure:"type"`
+
+	// Essence corresponds to the JSON schema field "essence".
+	Essence TransactionEssence `json:"essence" yaml:"essence" mapstructure:"essence"`
+
+	// Unlocks are kept untyped, as they are only needed for verification
+	Unlocks []map[string]interface{} `json:"unlocks" yaml:"unlocks" mapstructure:"unlocks"`
+}
+
+// An output with its metadata, as referenced by the inputs of a transaction
+type OutputWithMetadata struct {
+	// Metadata corresponds to the JSON schema field "metadata".
+	Metadata IOutputMetadataResponse `json:"metadata" yaml:"metadata" mapstructure:"metadata"`
+
+	// Output corresponds to the JSON schema field "output".
+	Output Output `json:"output" yaml:"output" mapstructure:"output"`
+}
+
+// A transaction of an account
+type Transaction struct {
+	// The block id in which the transaction payload was included
+	BlockId HexEncodedString `json:"blockId,omitempty" yaml:"blockId,omitempty" mapstructure:"blockId,omitempty"`
+
+	// The inclusion state of the transaction
+	InclusionState InclusionState `json:"inclusionState" yaml:"inclusionState" mapstructure:"inclusionState"`
+
+	// If the transaction was created by the wallet or someone else
+	Incoming bool `json:"incoming" yaml:"incoming" mapstructure:"incoming"`
+
+	// The network id in which the transaction was sent
+	NetworkId string `json:"networkId" yaml:"networkId" mapstructure:"networkId"`
+
+	// Note that can be set when sending a transaction and is only stored locally
+	Note string `json:"note,omitempty" yaml:"note,omitempty" mapstructure:"note,omitempty"`
+
+	// The transaction payload
+	Payload TransactionPayload `json:"payload" yaml:"payload" mapstructure:"payload"`
+
+	// The outputs consumed by the transaction, only known for transactions created by the wallet
+	Inputs []OutputWithMetadata `json:"inputs,omitempty" yaml:"inputs,omitempty" mapstructure:"inputs,omitempty"`
+
+	// The creation time in milliseconds
+	Timestamp string `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+
+	// The transaction id
+	TransactionId HexEncodedString `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
+
+// Outputs returns the outputs created by the transaction
+func (t *Transaction) Outputs() []Output {
+	return t.Payload.Essence.Outputs
+}
+
+// Time returns the creation time of the transaction
+func (t *Transaction) Time() (time.Time, error) {
+	milliseconds, err := strconv.ParseInt(t.Timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid transaction timestamp %q: %w", t.Timestamp, err)
+	}
+
+	return time.UnixMilli(milliseconds), nil
+}
+
+// Tagged data payload.
+type TaggedDataPayload struct {
+	// The tag to use to categorize the data.
+	Tag HexEncodedString `json:"tag" yaml:"tag" mapstructure:"tag"`
+
+	// The index data.
+	Data HexEncodedString `json:"data" yaml:"data" mapstructure:"data"`
+}
+
+// SendParams describes a transfer of base coins to an address
+type SendParams struct {
+	// Bech32 encoded address of the receiver
+	Address string `json:"address" yaml:"address" mapstructure:"address"`
+
+	// Amount of base coins, as decimal string
+	Amount string `json:"amount" yaml:"amount" mapstructure:"amount"`
+
+	// Bech32 encoded address the storage deposit is returned to, if the amount doesn't cover it. Defaults to the first address of the account.
+	ReturnAddress string `json:"returnAddress,omitempty" yaml:"returnAddress,omitempty" mapstructure:"returnAddress,omitempty"`
+
+	// Seconds after which the output can be reclaimed if the receiver didn't claim the storage deposit
+	Expiration uint32 `json:"expiration,omitempty" yaml:"expiration,omitempty" mapstructure:"expiration,omitempty"`
+}
+
+// Options for transactions
+type TransactionOptions struct {
+	// AllowMicroAmount corresponds to the JSON schema field "allowMicroAmount".
+	AllowMicroAmount bool `json:"allowMicroAmount,omitempty" yaml:"allowMicroAmount,omitempty" mapstructure:"allowMicroAmount,omitempty"`
+
+	// Burn corresponds to the JSON schema field "burn".
+	Burn *Burn `json:"burn,omitempty" yaml:"burn,omitempty" mapstructure:"burn,omitempty"`
+
+	// Custom inputs that should be used for the transaction
+	CustomInputs []OutputId `json:"customInputs,omitempty" yaml:"customInputs,omitempty" mapstructure:"customInputs,omitempty"`
+
+	// MandatoryInputs corresponds to the JSON schema field "mandatoryInputs".
+	MandatoryInputs []OutputId `json:"mandatoryInputs,omitempty" yaml:"mandatoryInputs,omitempty" mapstructure:"mandatoryInputs,omitempty"`
+
+	// Optional note, that is only stored locally
+	Note string `json:"note,omitempty" yaml:"note,omitempty" mapstructure:"note,omitempty"`
+
+	// RemainderValueStrategy corresponds to the JSON schema field
+	// "remainderValueStrategy".
+	RemainderValueStrategy TransactionOptionsRemainderValueStrategy `json:"remainderValueStrategy,omitempty" yaml:"remainderValueStrategy,omitempty" mapstructure:"remainderValueStrategy,omitempty"`
+
+	// Tagged data payload.
+	TaggedDataPayload *TaggedDataPayload `json:"taggedDataPayload,omitempty" yaml:"taggedDataPayload,omitempty" mapstructure:"taggedDataPayload,omitempty"`
+}
+
+// Either ReuseAddress, ChangeAddress or CustomAddress
+type TransactionOptionsRemainderValueStrategy interface{}
+
+// Amounts of native tokens to burn, by token ID
+type BurnNativeTokens map[HexEncodedString]HexEncodedAmount
+
+// Outputs and native tokens to burn
+type Burn struct {
+	// Aliases to burn
+	Aliases []HexEncodedString `json:"aliases,omitempty" yaml:"aliases,omitempty" mapstructure:"aliases,omitempty"`
+
+	// Foundries to burn
+	Foundries []HexEncodedString `json:"foundries,omitempty" yaml:"foundries,omitempty" mapstructure:"foundries,omitempty"`
+
+	// Amounts of native tokens to burn
+	NativeTokens BurnNativeTokens `json:"nativeTokens,omitempty" yaml:"nativeTokens,omitempty" mapstructure:"nativeTokens,omitempty"`
+
+	// NFTs to burn
+	Nfts []HexEncodedString `json:"nfts,omitempty" yaml:"nfts,omitempty" mapstructure:"nfts,omitempty"`
+}
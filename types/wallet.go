@@ -76,6 +76,15 @@ type Ed25519Signature struct {
 	Signature string `json:"signature" yaml:"signature" mapstructure:"signature"`
 }
 
+// Secp256k1EcdsaSignature is a recoverable signature of the keccak256 hash of a message, as used by EVM chains
+type Secp256k1EcdsaSignature struct {
+	// Compressed public key
+	PublicKey string `json:"publicKey" yaml:"publicKey" mapstructure:"publicKey"`
+
+	// Signature including the recovery ID
+	Signature string `json:"signature" yaml:"signature" mapstructure:"signature"`
+}
+
 // Secret manager that uses a mnemonic.
 type MnemonicSecretManager struct {
 	// Mnemonic corresponds to the JSON schema field "mnemonic".
@@ -4,6 +4,7 @@ import (
 	"github.com/awnumar/memguard"
 
 	"github.com/iotaledger/wasp-wallet-sdk/methods"
+	"github.com/iotaledger/wasp-wallet-sdk/types"
 )
 
 type Utils struct {
@@ -24,3 +25,34 @@ func (u *Utils) GenerateMnemonic() (*memguard.Enclave, error) {
 
 	return response, nil
 }
+
+// ParseBech32Address decodes a bech32 address into its typed form
+func (u *Utils) ParseBech32Address(address string) (*types.Address, error) {
+	parsed, free, err := u.sdk.CallUtilsMethod(methods.ParseBech32AddressMethod(methods.ParseBech32AddressMethodData{
+		Address: address,
+	}))
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[types.Address](parsed, err)
+}
+
+// VerifyMnemonic returns an error if the mnemonic is not a valid BIP39 mnemonic
+func (u *Utils) VerifyMnemonic(mnemonic *memguard.Enclave) error {
+	buffer, err := mnemonic.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	response, free, err := u.sdk.CallUtilsMethod(methods.VerifyMnemonicMethod(methods.VerifyMnemonicMethodData{
+		Mnemonic: buffer.String(),
+	}))
+	defer free()
+
+	_, err = methods.ParseResponseEnvelope(response, err)
+
+	return err
+}
@@ -3,9 +3,12 @@
 package wasp_wallet_sdk
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 
-	"github.com/ebitengine/purego"
+	"github.com/awnumar/memguard"
 
 	"github.com/iotaledger/wasp-wallet-sdk/methods"
 	"github.com/iotaledger/wasp-wallet-sdk/types"
@@ -16,9 +19,29 @@ type Wallet struct {
 	walletPtr        IotaWalletPtr
 	clientPtr        IotaClientPtr
 	secretManagerPtr IotaSecretManagerPtr
+	handle           *nativeHandle
+
+	// closed is set by Close, calls fail with ErrHandleClosed afterwards
+	closed atomic.Bool
+
+	// coinType of the wallet, used to default the bech32 HRP
+	coinType types.CoinType
+
+	// bech32Hrp overrides the HRP of the coin type for new accounts, see SetBech32Hrp
+	bech32Hrp string
+
+	// outputConsolidationThreshold the SDK applies to the wallet, 0 if it's unknown
+	outputConsolidationThreshold uint32
+
+	// listeners of the wallet events, see Listen
+	listeners *walletListeners
 }
 
 func (i *IOTASDK) CreateWallet(walletOptions types.WalletOptions) (wallet *Wallet, err error) {
+	if err := requireCapability(i.capabilities.Wallet, "create_wallet"); err != nil {
+		return nil, err
+	}
+
 	msg, free, err := SerializeGuarded(walletOptions)
 	defer free()
 	if err != nil {
@@ -30,34 +53,71 @@ func (i *IOTASDK) CreateWallet(walletOptions types.WalletOptions) (wallet *Walle
 		return nil, i.GetLastError()
 	}
 
-	clientPtr, err := i.GetClientFromWallet(walletPtr)
-	if err != nil {
-		return nil, err
+	i.handles.track(HandleKindWallet, uintptr(walletPtr), nil)
+
+	// Libraries without standalone client support can still be used for wallets, just without client access
+	var clientPtr IotaClientPtr
+	if i.capabilities.Client {
+		clientPtr, err = i.GetClientFromWallet(walletPtr)
+		if err != nil {
+			return nil, errors.Join(err, i.DestroyWallet(walletPtr))
+		}
 	}
 
 	secretManagerPtr, err := i.GetSecretManagerFromWallet(walletPtr)
 	if err != nil {
-		return nil, err
+		// Also destroys the client derived from the wallet
+		return nil, errors.Join(err, i.DestroyWallet(walletPtr))
 	}
 
-	return NewWallet(i, walletPtr, clientPtr, secretManagerPtr), nil
+	return NewWallet(i, walletPtr, clientPtr, secretManagerPtr, walletOptions), nil
 }
 
-func NewWallet(sdk *IOTASDK, walletPtr IotaWalletPtr, clientPtr IotaClientPtr, secretManagerPtr IotaSecretManagerPtr) *Wallet {
-	return &Wallet{
+// NewWallet wraps native wallet handles, the wallet takes ownership of the derived client and secret manager.
+// The options the native wallet was created with provide its coin type and output consolidation threshold.
+func NewWallet(sdk *IOTASDK, walletPtr IotaWalletPtr, clientPtr IotaClientPtr, secretManagerPtr IotaSecretManagerPtr, walletOptions types.WalletOptions) *Wallet {
+	handle := sdk.handles.track(HandleKindWallet, uintptr(walletPtr), nil)
+	if clientPtr != 0 {
+		sdk.handles.track(HandleKindClient, uintptr(clientPtr), handle)
+	}
+	if secretManagerPtr != 0 {
+		sdk.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), handle)
+	}
+
+	wallet := &Wallet{
 		sdk:              sdk,
 		walletPtr:        walletPtr,
 		clientPtr:        clientPtr,
 		secretManagerPtr: secretManagerPtr,
+		handle:           handle,
+		coinType:         walletOptions.CoinType,
+		listeners:        newWalletListeners(sdk),
+
+		outputConsolidationThreshold: types.OutputConsolidationThreshold(walletOptions.SecretManager),
 	}
+
+	watchLeak(sdk.handles, wallet, handle)
+
+	return wallet
 }
 
+// Close destroys the client and secret manager derived from the wallet, and the wallet itself.
+// Calls that are still running keep the wallet alive until they returned, later calls fail with ErrHandleClosed.
+func (s *Wallet) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+
+	return s.sdk.releaseTrackedHandle(s.handle)
+}
+
+// Destroy is Close without reporting errors
 func (s *Wallet) Destroy() {
-	_ = s.sdk.DestroyWallet(s.walletPtr)
+	_ = s.Close()
 }
 
 func (s *Wallet) GetLedgerStatus() (*types.LedgerNanoStatus, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallWalletMethod(s.walletPtr, methods.GetLedgerNanoStatusMethod())
+	ledgerNanoStatus, free, err := s.callMethod(methods.GetLedgerNanoStatusMethod())
 	defer free()
 
 	if err != nil {
@@ -72,48 +132,94 @@ func (s *Wallet) GetLedgerStatus() (*types.LedgerNanoStatus, error) {
 	return status, nil
 }
 
-func (s *Wallet) CreateAccount(alias string, bech32Hrp string, options *types.GenerateAddressOptions) (any, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallWalletMethod(s.walletPtr, methods.CreateAccountMethod(methods.CreateAccountPayloadMethodData{
-		Bech32Hrp: bech32Hrp,
-		Alias:     alias,
+// CoinType returns the coin type the wallet was created with
+func (s *Wallet) CoinType() types.CoinType {
+	return s.coinType
+}
+
+// SetBech32Hrp sets the HRP new accounts default to, e.g. the testnet HRP of a network. An empty HRP resets it to the one of the coin type.
+func (s *Wallet) SetBech32Hrp(bech32Hrp string) {
+	s.bech32Hrp = bech32Hrp
+}
+
+// Bech32Hrp returns the HRP new accounts default to
+func (s *Wallet) Bech32Hrp() (string, error) {
+	return types.ResolveBech32Hrp(s.coinType, s.bech32Hrp)
+}
+
+// CreateAccount creates a new account, an empty Bech32Hrp defaults to the HRP set by SetBech32Hrp or the one of the wallets coin type.
+// The context is only checked before the account is created, so a created account is always returned.
+func (s *Wallet) CreateAccount(ctx context.Context, options types.CreateAccountOptions) (*types.AccountDetails, error) {
+	if options.Bech32Hrp == "" {
+		options.Bech32Hrp = s.bech32Hrp
+	}
+
+	bech32Hrp, err := types.ResolveBech32Hrp(s.coinType, options.Bech32Hrp)
+	if err != nil {
+		return nil, err
+	}
+
+	accountDetails, free, err := awaitCall(ctx, func() ([]byte, func(), error) {
+		return s.callMethodContext(ctx, methods.CreateAccountMethod(methods.CreateAccountPayloadMethodData{
+			Alias:     options.Alias,
+			Bech32Hrp: bech32Hrp,
+			Addresses: options.Addresses,
+		}))
+	})
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	return methods.ParseResponse[types.AccountDetails](accountDetails, err)
+}
+
+// GetAccount returns the account by its index or alias
+func (s *Wallet) GetAccount(accountId types.AccountIdentifier) (*types.AccountDetails, error) {
+	accountDetails, free, err := s.callMethod(methods.GetAccountMethod(methods.GetAccountMethodData{
+		AccountID: accountId,
 	}))
 	defer free()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	address, err := methods.ParseResponse[any](ledgerNanoStatus, err)
-	if err != nil || address == nil {
-		return "", err
+	return methods.ParseResponse[types.AccountDetails](accountDetails, err)
+}
+
+func (s *Wallet) GetAccounts() ([]types.AccountDetails, error) {
+	accounts, free, err := s.callMethod(methods.GetAccountsMethod())
+	defer free()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := methods.ParseResponse[[]types.AccountDetails](accounts, err)
+	if err != nil || result == nil {
+		return nil, err
 	}
 
-	return *address, nil
+	return *result, nil
 }
 
-func (s *Wallet) GenerateEd25519Address(addressIndex uint32, accountIndex uint32, bech32Hrp string, options *types.GenerateAddressOptions) (string, error) {
-	ledgerNanoStatus, free, err := s.sdk.CallWalletMethod(s.walletPtr, methods.GenerateEd25519AddressMethod(methods.GenerateEd25519AddressMethodData{
-		AddressIndex: addressIndex,
-		AccountIndex: accountIndex,
-		Bech32Hrp:    bech32Hrp,
-		Options:      options,
-	}))
+func (s *Wallet) GetAccountIndexes() ([]uint32, error) {
+	accountIndexes, free, err := s.callMethod(methods.GetAccountIndexesMethod())
 	defer free()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	address, err := methods.ParseResponse[string](ledgerNanoStatus, err)
-	if err != nil || address == nil {
-		return "", err
+	result, err := methods.ParseResponse[[]uint32](accountIndexes, err)
+	if err != nil || result == nil {
+		return nil, err
 	}
 
-	return *address, nil
+	return *result, nil
 }
 
-func (s *Wallet) StoreMnemonic(mnemonic string) (bool, error) {
-	success, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.StoreMnemonicMethod(methods.StoreMnemonicMethodData{
-		Mnemonic: mnemonic,
-	}))
+// RemoveLatestAccount removes the account with the highest index, if it has no history and no balance
+func (s *Wallet) RemoveLatestAccount() (bool, error) {
+	success, free, err := s.callMethod(methods.RemoveLatestAccountMethod())
 	defer free()
 	if err != nil {
 		return false, err
@@ -122,13 +228,51 @@ func (s *Wallet) StoreMnemonic(mnemonic string) (bool, error) {
 	return methods.ParseResponseStatus(success, err)
 }
 
-func (s *Wallet) CallAccountMethod(accountId uint32, method types.BaseCallAccountMethodWrap[any]) (any, error) {
-	call := types.BaseCallAccountMethod[types.BaseCallAccountMethodWrap[any]]{
-		AccountId: accountId,
-		Method:    method,
+// GenerateEd25519Addresses generates a range of addresses, an empty bech32Hrp defaults to the HRP of the coin type
+func (s *Wallet) GenerateEd25519Addresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) ([]string, error) {
+	secretManager, err := s.SecretManager()
+	if err != nil {
+		return nil, err
 	}
+	defer secretManager.Destroy()
 
-	result, free, err := s.sdk.CallWalletMethod(s.walletPtr, methods.CallAccountMethod(call))
+	return secretManager.GenerateEd25519Addresses(addressRange, accountIndex, bech32Hrp, coinType, options)
+}
+
+// GenerateEd25519Address generates an address of the wallet, an empty bech32Hrp defaults to the HRP of the coin type
+func (s *Wallet) GenerateEd25519Address(addressIndex uint32, accountIndex uint32, bech32Hrp string, coinType types.CoinType, options *types.IGenerateAddressOptions) (string, error) {
+	secretManager, err := s.SecretManager()
+	if err != nil {
+		return "", err
+	}
+	defer secretManager.Destroy()
+
+	return secretManager.GenerateEd25519Address(addressIndex, accountIndex, bech32Hrp, coinType, options)
+}
+
+func (s *Wallet) GenerateEvmAddresses(addressRange types.Range, accountIndex uint32, bech32Hrp string, options *types.IGenerateAddressOptions) ([]string, error) {
+	secretManager, err := s.SecretManager()
+	if err != nil {
+		return nil, err
+	}
+	defer secretManager.Destroy()
+
+	return secretManager.GenerateEvmAddresses(addressRange, accountIndex, bech32Hrp, options)
+}
+
+// StoreMnemonic stores the mnemonic in the Stronghold snapshot of the wallet
+func (s *Wallet) StoreMnemonic(mnemonic *memguard.Enclave) (bool, error) {
+	secretManager, err := s.SecretManager()
+	if err != nil {
+		return false, err
+	}
+	defer secretManager.Destroy()
+
+	return secretManager.StoreMnemonic(mnemonic)
+}
+
+func (s *Wallet) CallAccountMethod(accountId uint32, method types.BaseCallAccountMethodWrap[any]) (any, error) {
+	result, free, err := s.callAccountMethod(context.Background(), accountId, method)
 	defer free()
 	if err != nil {
 		return false, err
@@ -137,41 +281,56 @@ func (s *Wallet) CallAccountMethod(accountId uint32, method types.BaseCallAccoun
 	return methods.ParseResponseStatus(result, err)
 }
 
+func (s *Wallet) callAccountMethod(ctx context.Context, accountId uint32, method types.BaseCallAccountMethodWrap[any]) ([]byte, func(), error) {
+	call := types.BaseCallAccountMethod[types.BaseCallAccountMethodWrap[any]]{
+		AccountId: accountId,
+		Method:    method,
+	}
+
+	return s.callMethodContext(ctx, methods.CallAccountMethod(call))
+}
+
 func (s *Wallet) SignTransactionEssence(txEssence types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Ed25519Signature, error) {
-	signedMessageStr, free, err := s.sdk.CallSecretManagerMethod(s.secretManagerPtr, methods.SignEd25519Method(methods.SignEd25519MethodData{
-		Message: txEssence,
-		Chain:   bip44Chain,
-	}))
-	defer free()
+	secretManager, err := s.SecretManager()
 	if err != nil {
 		return nil, err
 	}
+	defer secretManager.Destroy()
 
-	return methods.ParseResponse[types.Ed25519Signature](signedMessageStr, err)
+	return secretManager.SignTransactionEssence(txEssence, bip44Chain)
 }
 
-func (s *Wallet) ListenToUpdates() error {
-	events := "[0,1,2,3,4,5]"
-	eventsPtr, _ := CStringGo([]byte(events))
+// SignSecp256k1Ecdsa signs the keccak256 hash of the message with the secp256k1 key of the BIP44 chain, usually of coin type CoinTypeEther
+func (s *Wallet) SignSecp256k1Ecdsa(message types.HexEncodedString, bip44Chain types.Bip44Chain) (*types.Secp256k1EcdsaSignature, error) {
+	secretManager, err := s.SecretManager()
+	if err != nil {
+		return nil, err
+	}
+	defer secretManager.Destroy()
 
-	cb := func(b uintptr) {
-		fmt.Printf("HAI\n")
-		str, free, err := s.sdk.CopyAndDestroyOriginalStringPtr(b)
-		defer free()
+	return secretManager.SignSecp256k1Ecdsa(message, bip44Chain)
+}
 
-		// TODO
-		fmt.Println(err)
-		fmt.Println("LEDGER EVENT FIRED")
-		fmt.Printf("%s\n", string(str))
+// acquire retains the wallet for a native call, see IOTASDK.acquireHandle
+func (s *Wallet) acquire() (release func(), err error) {
+	if s.closed.Load() {
+		return func() {}, fmt.Errorf("%w: %s", ErrHandleClosed, HandleKindWallet)
 	}
 
-	cbptr := purego.NewCallback(cb)
+	return s.sdk.acquireHandle(HandleKindWallet, s.handle)
+}
+
+func (s *Wallet) callMethod(method any) ([]byte, func(), error) {
+	return s.callMethodContext(context.Background(), method)
+}
 
-	res := s.sdk.libListenWallet(s.walletPtr, eventsPtr, cbptr)
-	if !res {
-		fmt.Printf("ERR \n")
-		return s.sdk.GetLastError()
+// callMethodContext calls a method of the native wallet, which is retained during the call
+func (s *Wallet) callMethodContext(ctx context.Context, method any) ([]byte, func(), error) {
+	release, err := s.acquire()
+	if err != nil {
+		return nil, func() {}, err
 	}
+	defer release()
 
-	return nil
+	return s.sdk.CallWalletMethodContext(ctx, s.walletPtr, method)
 }
@@ -0,0 +1,97 @@
+package wasp_wallet_sdk
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/ebitengine/purego"
+
+	"github.com/iotaledger/wasp-wallet-sdk/types"
+)
+
+// walletListeners dispatches the events of a wallet to its handlers.
+// purego callbacks are never freed and their number is limited, so each wallet registers a single one for all event types.
+type walletListeners struct {
+	sdk *IOTASDK
+
+	mutex      sync.RWMutex
+	callback   uintptr
+	registered bool
+	handlers   []walletEventHandler
+}
+
+type walletEventHandler struct {
+	eventTypes []types.WalletEventType
+	handler    func(types.WalletEvent)
+}
+
+func newWalletListeners(sdk *IOTASDK) *walletListeners {
+	return &walletListeners{sdk: sdk}
+}
+
+// Listen calls handler for each event of the given types emitted by the wallet, an empty list of types listens to all events.
+// The handler is called from a native thread and should return quickly.
+func (s *Wallet) Listen(eventTypes []types.WalletEventType, handler func(types.WalletEvent)) error {
+	if err := requireCapability(s.sdk.capabilities.WalletEvents, "listen_wallet"); err != nil {
+		return err
+	}
+
+	release, err := s.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s.listeners.mutex.Lock()
+	defer s.listeners.mutex.Unlock()
+
+	if !s.listeners.registered {
+		if s.listeners.callback == 0 {
+			s.listeners.callback = purego.NewCallback(s.listeners.dispatch)
+		}
+
+		// An empty list listens to all events, they are filtered by dispatch
+		eventsPtr, free := CStringGo([]byte("[]"))
+		defer free()
+
+		if !s.sdk.libListenWallet(s.walletPtr, eventsPtr, s.listeners.callback) {
+			return s.sdk.GetLastError()
+		}
+		s.listeners.registered = true
+	}
+
+	s.listeners.handlers = append(s.listeners.handlers, walletEventHandler{
+		eventTypes: slices.Clone(eventTypes),
+		handler:    handler,
+	})
+
+	return nil
+}
+
+// dispatch is the native callback, it calls the handlers listening to the type of the event
+func (l *walletListeners) dispatch(eventPtr uintptr) {
+	serializedEvent, free, err := l.sdk.CopyAndDestroyOriginalStringPtr(eventPtr)
+	defer free()
+	if err != nil {
+		return
+	}
+
+	var event types.WalletEvent
+	if err := json.Unmarshal(serializedEvent, &event); err != nil {
+		return
+	}
+
+	// Events of an unknown type only go to the handlers listening to all events
+	eventType, typeErr := event.Type()
+
+	l.mutex.RLock()
+	handlers := slices.Clone(l.handlers)
+	l.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		if len(handler.eventTypes) == 0 || (typeErr == nil && slices.Contains(handler.eventTypes, eventType)) {
+			handler.handler(event)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package wasp_wallet_sdk
+
+import (
+	"errors"
+
+	"github.com/awnumar/memguard"
+
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
+)
+
+// Backup writes the wallet data and the Stronghold secrets to a snapshot at destination, encrypted with the password
+func (s *Wallet) Backup(destination string, password *memguard.Enclave) error {
+	buffer, err := password.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	return s.callWalletStatusMethod(methods.BackupMethod(methods.BackupMethodData{
+		Destination: destination,
+		Password:    buffer.String(),
+	}))
+}
+
+// RestoreBackup replaces the wallet data and the Stronghold secrets with the ones of a backup.
+// If ignoreCoinTypeMismatch is set and the backup has another coin type, its accounts and coin type are not restored, only its secrets.
+func (s *Wallet) RestoreBackup(source string, password *memguard.Enclave, ignoreCoinTypeMismatch bool) error {
+	buffer, err := password.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	return s.callWalletStatusMethod(methods.RestoreBackupMethod(methods.RestoreBackupMethodData{
+		Source:                   source,
+		Password:                 buffer.String(),
+		IgnoreIfCoinTypeMismatch: ignoreCoinTypeMismatch,
+	}))
+}
+
+// ChangeStrongholdPassword re-encrypts the Stronghold snapshot of the wallet
+func (s *Wallet) ChangeStrongholdPassword(currentPassword *memguard.Enclave, newPassword *memguard.Enclave) error {
+	currentBuffer, err := currentPassword.Open()
+	if err != nil {
+		return err
+	}
+	defer currentBuffer.Destroy()
+
+	newBuffer, err := newPassword.Open()
+	if err != nil {
+		return err
+	}
+	defer newBuffer.Destroy()
+
+	return s.callWalletStatusMethod(methods.ChangeStrongholdPasswordMethod(methods.ChangeStrongholdPasswordMethodData{
+		CurrentPassword: currentBuffer.String(),
+		NewPassword:     newBuffer.String(),
+	}))
+}
+
+// SetStrongholdPassword unlocks the Stronghold snapshot of the wallet, e.g. after the password was cleared
+func (s *Wallet) SetStrongholdPassword(password *memguard.Enclave) error {
+	buffer, err := password.Open()
+	if err != nil {
+		return err
+	}
+	defer buffer.Destroy()
+
+	return s.callWalletStatusMethod(methods.SetStrongholdPasswordMethod(methods.SetStrongholdPasswordMethodData{
+		Password: buffer.String(),
+	}))
+}
+
+func (s *Wallet) callWalletStatusMethod(method any) error {
+	response, free, err := s.callMethod(method)
+	defer free()
+	if err != nil {
+		return err
+	}
+
+	success, err := methods.ParseResponseStatus(response, err)
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return errors.New("wallet method did not succeed")
+	}
+
+	return nil
+}
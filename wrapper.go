@@ -1,12 +1,20 @@
 package wasp_wallet_sdk
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/awnumar/memguard"
 	"github.com/goccy/go-json"
 
 	"github.com/iotaledger/wasp-wallet-sdk/lib_loader"
+	"github.com/iotaledger/wasp-wallet-sdk/methods"
 	"github.com/iotaledger/wasp-wallet-sdk/types"
 
 	"github.com/ebitengine/purego"
@@ -44,6 +52,16 @@ type IOTASDK struct {
 
 	libListenWallet func(IotaWalletPtr, *byte, uintptr) bool // uintptr is a callback (purego.NewCallback)
 	libGetLastError func() string
+	libGetVersion   func() string
+
+	capabilities Capabilities
+	handles      *handleRegistry
+	logger       atomic.Pointer[slog.Logger]
+
+	interceptors      atomic.Pointer[[]Interceptor]
+	interceptorsMutex sync.Mutex
+
+	closeOnce sync.Once
 }
 
 // Encodes an object into a JSON string protected by memguard
@@ -76,6 +94,44 @@ func SerializeGuarded(obj any) (ProtectedStringPtr, func(), error) {
 	}, nil
 }
 
+// MinimumBindingVersion is the lowest native binding version this wrapper is compatible with.
+// It is only enforced if the library exports its version.
+const MinimumBindingVersion = "1.1.0"
+
+var (
+	ErrIncompatibleLibrary   = errors.New("incompatible IOTA SDK native library")
+	ErrCapabilityUnavailable = errors.New("capability not available in the loaded IOTA SDK native library")
+)
+
+// Capabilities of the loaded native library, probed by its exported symbols
+type Capabilities struct {
+	// Logger is true if the native logger can be initialized
+	Logger bool
+	// Client is true if standalone clients can be created
+	Client bool
+	// Wallet is true if wallets can be created
+	Wallet bool
+	// WalletEvents is true if wallet events can be listened to
+	WalletEvents bool
+	// Version is true if the library exports its version
+	Version bool
+}
+
+type libSymbol struct {
+	fptr any
+	name string
+}
+
+// NewIotaSDKFromEnv loads the native library found by lib_loader.FindLibrary
+func NewIotaSDKFromEnv() (*IOTASDK, error) {
+	libPath, err := lib_loader.FindLibrary()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIotaSDK(libPath)
+}
+
 func NewIotaSDK(libPath string) (*IOTASDK, error) {
 	iotaSDK, err := lib_loader.LoadLibrary(libPath)
 	if err != nil {
@@ -83,40 +139,184 @@ func NewIotaSDK(libPath string) (*IOTASDK, error) {
 	}
 
 	iotaSDKNative := IOTASDK{
-		handle: iotaSDK,
+		handle:  iotaSDK,
+		handles: newHandleRegistry(),
 	}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libInitLogger, iotaSDK, "init_logger")
+	if err := iotaSDKNative.registerSymbols(); err != nil {
+		_ = lib_loader.UnloadLibrary(iotaSDK)
+		return nil, fmt.Errorf("%w %s: %v", ErrIncompatibleLibrary, libPath, err)
+	}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libCreateClient, iotaSDK, "create_client")
-	purego.RegisterLibFunc(&iotaSDKNative.libCreateWallet, iotaSDK, "create_wallet")
-	purego.RegisterLibFunc(&iotaSDKNative.libCreateSecretManager, iotaSDK, "create_secret_manager")
+	if err := iotaSDKNative.CheckVersion(); err != nil {
+		_ = lib_loader.UnloadLibrary(iotaSDK)
+		return nil, fmt.Errorf("%w %s: %v", ErrIncompatibleLibrary, libPath, err)
+	}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libDestroyClient, iotaSDK, "destroy_client")
-	purego.RegisterLibFunc(&iotaSDKNative.libDestroyWallet, iotaSDK, "destroy_wallet")
-	purego.RegisterLibFunc(&iotaSDKNative.libDestroySecretManager, iotaSDK, "destroy_secret_manager")
-	purego.RegisterLibFunc(&iotaSDKNative.libDestroyString, iotaSDK, "destroy_string")
+	return &iotaSDKNative, nil
+}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libGetClientFromWallet, iotaSDK, "get_client_from_wallet")
-	purego.RegisterLibFunc(&iotaSDKNative.libGetSecretManagerFromWallet, iotaSDK, "get_secret_manager_from_wallet")
+// registerSymbols binds all exported functions. Missing required symbols return an error,
+// missing optional symbols disable the corresponding capability.
+func (i *IOTASDK) registerSymbols() error {
+	required := []libSymbol{
+		{&i.libCreateSecretManager, "create_secret_manager"},
+		{&i.libDestroySecretManager, "destroy_secret_manager"},
+		{&i.libDestroyString, "destroy_string"},
+		{&i.libCallSecretManagerMethod, "call_secret_manager_method"},
+		{&i.libCallUtilsMethod, "call_utils_method"},
+		{&i.libGetLastError, "binding_get_last_error"},
+	}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libCallClientMethod, iotaSDK, "call_client_method")
-	purego.RegisterLibFunc(&iotaSDKNative.libCallWalletMethod, iotaSDK, "call_wallet_method")
-	purego.RegisterLibFunc(&iotaSDKNative.libCallSecretManagerMethod, iotaSDK, "call_secret_manager_method")
-	purego.RegisterLibFunc(&iotaSDKNative.libCallUtilsMethod, iotaSDK, "call_utils_method")
+	if missing := i.registerLibSymbols(required); len(missing) > 0 {
+		return fmt.Errorf("missing required symbols: %s", strings.Join(missing, ", "))
+	}
 
-	purego.RegisterLibFunc(&iotaSDKNative.libListenWallet, iotaSDK, "listen_wallet")
-	purego.RegisterLibFunc(&iotaSDKNative.libGetLastError, iotaSDK, "binding_get_last_error")
+	i.capabilities = Capabilities{
+		Logger: len(i.registerLibSymbols([]libSymbol{
+			{&i.libInitLogger, "init_logger"},
+		})) == 0,
+		Client: len(i.registerLibSymbols([]libSymbol{
+			{&i.libCreateClient, "create_client"},
+			{&i.libDestroyClient, "destroy_client"},
+			{&i.libCallClientMethod, "call_client_method"},
+		})) == 0,
+		Wallet: len(i.registerLibSymbols([]libSymbol{
+			{&i.libCreateWallet, "create_wallet"},
+			{&i.libDestroyWallet, "destroy_wallet"},
+			{&i.libGetClientFromWallet, "get_client_from_wallet"},
+			{&i.libGetSecretManagerFromWallet, "get_secret_manager_from_wallet"},
+			{&i.libCallWalletMethod, "call_wallet_method"},
+		})) == 0,
+		WalletEvents: len(i.registerLibSymbols([]libSymbol{
+			{&i.libListenWallet, "listen_wallet"},
+		})) == 0,
+		Version: len(i.registerLibSymbols([]libSymbol{
+			{&i.libGetVersion, "binding_get_version"},
+		})) == 0,
+	}
 
-	return &iotaSDKNative, nil
+	return nil
+}
+
+// registerLibSymbols binds either all symbols of a group or none of them, and returns the missing ones
+func (i *IOTASDK) registerLibSymbols(symbols []libSymbol) (missing []string) {
+	addresses := make([]uintptr, len(symbols))
+	for index, symbol := range symbols {
+		address, err := lib_loader.LookupSymbol(i.handle, symbol.name)
+		if err != nil || address == 0 {
+			missing = append(missing, symbol.name)
+			continue
+		}
+
+		addresses[index] = address
+	}
+
+	if len(missing) > 0 {
+		return missing
+	}
+
+	for index, symbol := range symbols {
+		purego.RegisterFunc(symbol.fptr, addresses[index])
+	}
+
+	return nil
+}
+
+// versionProbeMethod is a utils method added in MinimumBindingVersion, it identifies libraries without a version symbol
+const versionProbeMethod = "verifySecp256k1EcdsaSignature"
+
+// CheckVersion returns an error if the library is older than MinimumBindingVersion.
+// Libraries without a version symbol are probed with a utils method they have to know, and only rejected if they don't know it.
+func (i *IOTASDK) CheckVersion() error {
+	minimumVersion, err := lib_loader.ParseVersion(MinimumBindingVersion)
+	if err != nil {
+		return err
+	}
+
+	if !i.capabilities.Version {
+		return i.probeVersion(minimumVersion)
+	}
+
+	version, err := i.Version()
+	if err != nil {
+		return err
+	}
+
+	if version.Compare(minimumVersion) < 0 {
+		return fmt.Errorf("binding version %s is older than the minimum supported version %s", version, minimumVersion)
+	}
+
+	return nil
+}
+
+// probeVersion calls the probe method without its arguments, only a library that doesn't know the method name is too old.
+// Any other outcome, usually an error about the missing arguments, means the version is unknown but new enough to know the method.
+func (i *IOTASDK) probeVersion(minimumVersion lib_loader.Version) error {
+	response, free, err := i.CallUtilsMethod(methods.NewBaseRequest(versionProbeMethod, struct{}{}))
+	defer free()
+
+	if methodErr := methodErrorOf(response, DomainUtils, versionProbeMethod); err == nil && methodErr != nil {
+		err = methodErr
+	}
+	if err != nil && strings.Contains(err.Error(), "unknown variant") {
+		return fmt.Errorf("binding version is older than the minimum supported version %s: %s is unknown", minimumVersion, versionProbeMethod)
+	}
+
+	return nil
+}
+
+// Capabilities returns which optional features the loaded native library supports
+func (i *IOTASDK) Capabilities() Capabilities {
+	return i.capabilities
+}
+
+// Version returns the version of the native binding, if the library exports it
+func (i *IOTASDK) Version() (lib_loader.Version, error) {
+	if err := requireCapability(i.capabilities.Version, "binding_get_version"); err != nil {
+		return lib_loader.Version{}, err
+	}
+
+	return lib_loader.ParseVersion(i.libGetVersion())
+}
+
+func requireCapability(available bool, name string) error {
+	if !available {
+		return fmt.Errorf("%w: %s", ErrCapabilityUnavailable, name)
+	}
+
+	return nil
 }
 
 func (i *IOTASDK) Utils() *Utils {
 	return &Utils{sdk: i}
 }
 
+// Close destroys all live wallets, clients and secret managers, children before their parents, and unloads the library.
+// The SDK and all objects created by it must not be used afterwards, closing it again is a no-op.
+func (i *IOTASDK) Close() error {
+	var err error
+	i.closeOnce.Do(func() {
+		err = i.close()
+	})
+
+	return err
+}
+
+func (i *IOTASDK) close() error {
+	err := i.closeAllHandles()
+
+	// Replay SDKs have no library loaded
+	if i.handle == 0 {
+		return err
+	}
+
+	return errors.Join(err, lib_loader.UnloadLibrary(i.handle))
+}
+
+// Destroy is Close without reporting errors
 func (i *IOTASDK) Destroy() {
-	_ = lib_loader.UnloadLibrary(i.handle)
+	_ = i.Close()
 }
 
 func (i *IOTASDK) GetLastError() error {
@@ -129,6 +329,10 @@ func (i *IOTASDK) GetLastError() error {
 }
 
 func (i *IOTASDK) InitLogger(loggerConfig types.ILoggerConfig) (bool, error) {
+	if err := requireCapability(i.capabilities.Logger, "init_logger"); err != nil {
+		return false, err
+	}
+
 	msg, free, err := SerializeGuarded(loggerConfig)
 	defer free()
 	if err != nil {
@@ -143,6 +347,10 @@ func (i *IOTASDK) InitLogger(loggerConfig types.ILoggerConfig) (bool, error) {
 }
 
 func (i *IOTASDK) CreateClient(clientOptions types.ClientOptions) (clientPtr IotaClientPtr, err error) {
+	if err := requireCapability(i.capabilities.Client, "create_client"); err != nil {
+		return 0, err
+	}
+
 	msg, free, err := SerializeGuarded(clientOptions)
 	defer free()
 	if err != nil {
@@ -153,6 +361,8 @@ func (i *IOTASDK) CreateClient(clientOptions types.ClientOptions) (clientPtr Iot
 		return 0, i.GetLastError()
 	}
 
+	i.handles.track(HandleKindClient, uintptr(clientPtr), nil)
+
 	return clientPtr, nil
 }
 
@@ -167,87 +377,175 @@ func (i *IOTASDK) CreateSecretManager(secretManagerOptions types.WalletOptionsSe
 		return 0, i.GetLastError()
 	}
 
+	i.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), nil)
+
 	return secretManagerPtr, nil
 }
 
 func (i *IOTASDK) GetClientFromWallet(iotaWalletPtr IotaWalletPtr) (clientPtr IotaClientPtr, err error) {
+	if err := requireCapability(i.capabilities.Wallet && i.capabilities.Client, "get_client_from_wallet"); err != nil {
+		return 0, err
+	}
+
 	if clientPtr = i.libGetClientFromWallet(iotaWalletPtr); clientPtr == 0 {
 		return 0, i.GetLastError()
 	}
 
+	i.handles.track(HandleKindClient, uintptr(clientPtr), i.handles.lookup(HandleKindWallet, uintptr(iotaWalletPtr)))
+
 	return clientPtr, nil
 }
 
 func (i *IOTASDK) GetSecretManagerFromWallet(iotaWalletPtr IotaWalletPtr) (secretManagerPtr IotaSecretManagerPtr, err error) {
+	if err := requireCapability(i.capabilities.Wallet, "get_secret_manager_from_wallet"); err != nil {
+		return 0, err
+	}
+
 	if secretManagerPtr = i.libGetSecretManagerFromWallet(iotaWalletPtr); secretManagerPtr == 0 {
 		return 0, i.GetLastError()
 	}
 
+	i.handles.track(HandleKindSecretManager, uintptr(secretManagerPtr), i.handles.lookup(HandleKindWallet, uintptr(iotaWalletPtr)))
+
 	return secretManagerPtr, nil
 }
 
 func (i *IOTASDK) CallUtilsMethod(method any) (response []byte, free func(), err error) {
-	msg, free, err := SerializeGuarded(method)
-	defer free()
-	if err != nil {
+	return i.CallUtilsMethodContext(context.Background(), method)
+}
+
+// CallUtilsMethodContext is CallUtilsMethod with the context passed to the interceptors
+func (i *IOTASDK) CallUtilsMethodContext(ctx context.Context, method any) (response []byte, free func(), err error) {
+	return i.callMethod(ctx, DomainUtils, method, func(msg *byte) uintptr {
+		return i.libCallUtilsMethod(msg)
+	})
+}
+
+func (i *IOTASDK) CallClientMethod(iotaClientPtr IotaClientPtr, method any) (response []byte, free func(), err error) {
+	return i.CallClientMethodContext(context.Background(), iotaClientPtr, method)
+}
+
+// CallClientMethodContext is CallClientMethod with the context passed to the interceptors
+func (i *IOTASDK) CallClientMethodContext(ctx context.Context, iotaClientPtr IotaClientPtr, method any) (response []byte, free func(), err error) {
+	if err := requireCapability(i.capabilities.Client, "call_client_method"); err != nil {
 		return nil, func() {}, err
 	}
 
-	var responsePtr uintptr
-	if responsePtr = i.libCallUtilsMethod(msg); responsePtr == 0 {
-		return nil, func() {}, i.GetLastError()
-	}
+	return i.callMethod(ctx, DomainClient, method, func(msg *byte) uintptr {
+		return i.libCallClientMethod(iotaClientPtr, msg)
+	})
+}
 
-	return i.CopyAndDestroyOriginalStringPtr(responsePtr)
+func (i *IOTASDK) CallWalletMethod(iotaWalletPtr IotaWalletPtr, method any) ([]byte, func(), error) {
+	return i.CallWalletMethodContext(context.Background(), iotaWalletPtr, method)
 }
 
-func (i *IOTASDK) CallClientMethod(iotaClientPtr IotaClientPtr, method any) (response []byte, free func(), err error) {
-	msg, free, err := SerializeGuarded(method)
-	defer free()
-	if err != nil {
+// CallWalletMethodContext is CallWalletMethod with the context passed to the interceptors
+func (i *IOTASDK) CallWalletMethodContext(ctx context.Context, iotaWalletPtr IotaWalletPtr, method any) ([]byte, func(), error) {
+	if err := requireCapability(i.capabilities.Wallet, "call_wallet_method"); err != nil {
 		return nil, func() {}, err
 	}
 
-	var responsePtr uintptr
-	if responsePtr = i.libCallClientMethod(iotaClientPtr, msg); responsePtr == 0 {
-		return nil, func() {}, i.GetLastError()
-	}
+	return i.callMethod(ctx, DomainWallet, method, func(msg *byte) uintptr {
+		return i.libCallWalletMethod(iotaWalletPtr, msg)
+	})
+}
 
-	return i.CopyAndDestroyOriginalStringPtr(responsePtr)
+func (i *IOTASDK) CallSecretManagerMethod(iotaSecretManagerPtr IotaSecretManagerPtr, method any) ([]byte, func(), error) {
+	return i.CallSecretManagerMethodContext(context.Background(), iotaSecretManagerPtr, method)
 }
 
-func (i *IOTASDK) CallWalletMethod(iotaWalletPtr IotaWalletPtr, method any) ([]byte, func(), error) {
-	msg, free, err := SerializeGuarded(method)
-	defer free()
-	if err != nil {
+// CallSecretManagerMethodContext is CallSecretManagerMethod with the context passed to the interceptors
+func (i *IOTASDK) CallSecretManagerMethodContext(ctx context.Context, iotaSecretManagerPtr IotaSecretManagerPtr, method any) ([]byte, func(), error) {
+	return i.callMethod(ctx, DomainSecretManager, method, func(msg *byte) uintptr {
+		return i.libCallSecretManagerMethod(iotaSecretManagerPtr, msg)
+	})
+}
+
+// callMethod runs the call through the interceptors, every invocation serializes the method, sends it over the message bus and copies the response
+func (i *IOTASDK) callMethod(ctx context.Context, domain Domain, method any, call func(msg *byte) uintptr) (response []byte, free func(), err error) {
+	start := time.Now()
+	defer func() {
+		i.logMethodCall(domain, method, time.Since(start), err)
+	}()
+
+	return i.interceptCall(ctx, domain, method, func() ([]byte, func(), error) {
+		msg, freeMsg, err := SerializeGuarded(method)
+		defer freeMsg()
+		if err != nil {
+			return nil, func() {}, err
+		}
+
+		var responsePtr uintptr
+		if responsePtr = call(msg); responsePtr == 0 {
+			return nil, func() {}, i.GetLastError()
+		}
+
+		return i.CopyAndDestroyOriginalStringPtr(responsePtr)
+	})
+}
+
+// callWithContext runs a blocking native call and returns early if the context is done before the call finished.
+// Cancellation is advisory: native calls can't be interrupted, so an abandoned call still finishes in the background,
+// keeps the handle it was made on alive until then and its response gets freed.
+// Only use it for calls that can be repeated safely, calls with side effects use awaitCall.
+func callWithContext(ctx context.Context, call func() ([]byte, func(), error)) ([]byte, func(), error) {
+	if err := ctx.Err(); err != nil {
 		return nil, func() {}, err
 	}
 
-	var responsePtr uintptr
-	if responsePtr = i.libCallWalletMethod(iotaWalletPtr, msg); responsePtr == 0 {
-		return nil, func() {}, i.GetLastError()
+	type callResult struct {
+		response []byte
+		free     func()
+		err      error
 	}
 
-	return i.CopyAndDestroyOriginalStringPtr(responsePtr)
+	done := make(chan callResult, 1)
+	go func() {
+		response, free, err := call()
+		done <- callResult{response: response, free: free, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.response, result.free, result.err
+	case <-ctx.Done():
+		go func() {
+			result := <-done
+			result.free()
+		}()
+
+		return nil, func() {}, ctx.Err()
+	}
 }
 
-func (i *IOTASDK) CallSecretManagerMethod(iotaSecretManagerPtr IotaSecretManagerPtr, method any) ([]byte, func(), error) {
-	msg, free, err := SerializeGuarded(method)
-	defer free()
-	if err != nil {
+// awaitCall runs a blocking native call that must not be abandoned, e.g. one that creates an account or posts a block.
+// The context is only checked before the call, afterwards the call's result is returned, so the caller knows whether it took effect.
+func awaitCall(ctx context.Context, call func() ([]byte, func(), error)) ([]byte, func(), error) {
+	if err := ctx.Err(); err != nil {
 		return nil, func() {}, err
 	}
 
-	var responsePtr uintptr
-	if responsePtr = i.libCallSecretManagerMethod(iotaSecretManagerPtr, msg); responsePtr == 0 {
-		return nil, func() {}, i.GetLastError()
-	}
-
-	return i.CopyAndDestroyOriginalStringPtr(responsePtr)
+	return call()
 }
 
+// DestroyClient releases a client, it's destroyed once it has no owner left
 func (i *IOTASDK) DestroyClient(client IotaClientPtr) (err error) {
-	if client == 0 {
+	return i.releaseHandle(HandleKindClient, uintptr(client))
+}
+
+// DestroyWallet releases a wallet, it's destroyed together with its derived client and secret manager once it has no owner left
+func (i *IOTASDK) DestroyWallet(wallet IotaWalletPtr) (err error) {
+	return i.releaseHandle(HandleKindWallet, uintptr(wallet))
+}
+
+// DestroySecretManager releases a secret manager, it's destroyed once it has no owner left
+func (i *IOTASDK) DestroySecretManager(secretManager IotaSecretManagerPtr) (err error) {
+	return i.releaseHandle(HandleKindSecretManager, uintptr(secretManager))
+}
+
+func (i *IOTASDK) destroyNativeClient(client IotaClientPtr) (err error) {
+	if client == 0 || i.libDestroyClient == nil {
 		return nil
 	}
 
@@ -258,8 +556,8 @@ func (i *IOTASDK) DestroyClient(client IotaClientPtr) (err error) {
 	return nil
 }
 
-func (i *IOTASDK) DestroyWallet(wallet IotaWalletPtr) (err error) {
-	if wallet == 0 {
+func (i *IOTASDK) destroyNativeWallet(wallet IotaWalletPtr) (err error) {
+	if wallet == 0 || i.libDestroyWallet == nil {
 		return nil
 	}
 
@@ -270,7 +568,7 @@ func (i *IOTASDK) DestroyWallet(wallet IotaWalletPtr) (err error) {
 	return nil
 }
 
-func (i *IOTASDK) DestroySecretManager(secretManager IotaSecretManagerPtr) (err error) {
+func (i *IOTASDK) destroyNativeSecretManager(secretManager IotaSecretManagerPtr) (err error) {
 	if secretManager == 0 {
 		return nil
 	}